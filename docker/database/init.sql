@@ -1,4 +1,4 @@
-CREATE EXTENSION vector;
+CREATE EXTENSION IF NOT EXISTS vector;
 
 CREATE TABLE IF NOT EXISTS translations_cache (
     id SERIAL PRIMARY KEY,
@@ -6,7 +6,41 @@ CREATE TABLE IF NOT EXISTS translations_cache (
     target_language text NOT NULL,
     source_text text NOT NULL,
     target_text text NOT NULL,
-    embedding vector(384)
+    source_hash text NOT NULL,
+    glossary_id integer NOT NULL DEFAULT 0,
+    embedding vector,
+    embedding_model text NOT NULL,
+    embedding_dimensions integer NOT NULL,
+    source text NOT NULL DEFAULT 'machine' CHECK (source IN ('machine', 'human', 'imported')),
+    priority integer NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    last_hit_at timestamptz,
+    hit_count integer NOT NULL DEFAULT 0
 );
 
-CREATE INDEX idx_translations_cache_embedding ON translations_cache USING ivfflat (embedding vector_cosine_ops) WITH (lists = 100);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_translations_cache_source_hash ON translations_cache (source_language, target_language, glossary_id, source_hash);
+
+CREATE INDEX IF NOT EXISTS idx_translations_cache_embedding_model ON translations_cache (embedding_model);
+
+CREATE INDEX IF NOT EXISTS idx_translations_cache_created_at ON translations_cache (source_language, target_language, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_translations_cache_embedding_384 ON translations_cache USING ivfflat ((embedding::vector(384)) vector_cosine_ops) WITH (lists = 100) WHERE embedding_dimensions = 384;
+
+CREATE TABLE IF NOT EXISTS glossaries (
+    id SERIAL PRIMARY KEY,
+    tenant text NOT NULL DEFAULT '',
+    source_language text NOT NULL,
+    target_language text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_glossaries_pair ON glossaries (tenant, source_language, target_language);
+
+CREATE TABLE IF NOT EXISTS glossary_terms (
+    glossary_id integer NOT NULL REFERENCES glossaries (id) ON DELETE CASCADE,
+    source_term text NOT NULL,
+    target_term text NOT NULL,
+    ignore_case boolean NOT NULL DEFAULT false,
+    PRIMARY KEY (glossary_id, source_term)
+);
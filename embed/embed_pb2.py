@@ -24,7 +24,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0b\x65mbed.proto\x12\x05\x65mbed\" \n\x10\x45mbeddingRequest\x12\x0c\n\x04text\x18\x01 \x01(\t\"&\n\x11\x45mbeddingResponse\x12\x11\n\tembedding\x18\x01 \x03(\x02\x32R\n\x08\x45mbedder\x12\x46\n\x11GenerateEmbedding\x12\x17.embed.EmbeddingRequest\x1a\x18.embed.EmbeddingResponseb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0b\x65mbed.proto\x12\x05\x65mbed\" \n\x10\x45mbeddingRequest\x12\x0c\n\x04text\x18\x01 \x01(\t\"5\n\x11\x45mbeddingResponse\x12\x11\n\tembedding\x18\x01 \x03(\x02\x12\r\n\x05model\x18\x02 \x01(\t\"\"\n\x11\x45mbeddingsRequest\x12\r\n\x05texts\x18\x01 \x03(\t\"\x1b\n\tEmbedding\x12\x0e\n\x06values\x18\x01 \x03(\x02\"I\n\x12\x45mbeddingsResponse\x12$\n\nembeddings\x18\x01 \x03(\x0b\x32\x10.embed.Embedding\x12\r\n\x05model\x18\x02 \x01(\t2\x9d\x01\n\x08\x45mbedder\x12\x46\n\x11GenerateEmbedding\x12\x17.embed.EmbeddingRequest\x1a\x18.embed.EmbeddingResponse\x12I\n\x12GenerateEmbeddings\x12\x18.embed.EmbeddingsRequest\x1a\x19.embed.EmbeddingsResponseb\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -34,7 +34,13 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_EMBEDDINGREQUEST']._serialized_start=22
   _globals['_EMBEDDINGREQUEST']._serialized_end=54
   _globals['_EMBEDDINGRESPONSE']._serialized_start=56
-  _globals['_EMBEDDINGRESPONSE']._serialized_end=94
-  _globals['_EMBEDDER']._serialized_start=96
-  _globals['_EMBEDDER']._serialized_end=178
+  _globals['_EMBEDDINGRESPONSE']._serialized_end=109
+  _globals['_EMBEDDINGSREQUEST']._serialized_start=111
+  _globals['_EMBEDDINGSREQUEST']._serialized_end=145
+  _globals['_EMBEDDING']._serialized_start=147
+  _globals['_EMBEDDING']._serialized_end=174
+  _globals['_EMBEDDINGSRESPONSE']._serialized_start=176
+  _globals['_EMBEDDINGSRESPONSE']._serialized_end=249
+  _globals['_EMBEDDER']._serialized_start=252
+  _globals['_EMBEDDER']._serialized_end=409
 # @@protoc_insertion_point(module_scope)
@@ -40,6 +40,11 @@ class EmbedderStub(object):
                 request_serializer=embed__pb2.EmbeddingRequest.SerializeToString,
                 response_deserializer=embed__pb2.EmbeddingResponse.FromString,
                 _registered_method=True)
+        self.GenerateEmbeddings = channel.unary_unary(
+                '/embed.Embedder/GenerateEmbeddings',
+                request_serializer=embed__pb2.EmbeddingsRequest.SerializeToString,
+                response_deserializer=embed__pb2.EmbeddingsResponse.FromString,
+                _registered_method=True)
 
 
 class EmbedderServicer(object):
@@ -53,6 +58,13 @@ class EmbedderServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def GenerateEmbeddings(self, request, context):
+        """Generates embeddings for many texts in a single model call.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_EmbedderServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -61,6 +73,11 @@ def add_EmbedderServicer_to_server(servicer, server):
                     request_deserializer=embed__pb2.EmbeddingRequest.FromString,
                     response_serializer=embed__pb2.EmbeddingResponse.SerializeToString,
             ),
+            'GenerateEmbeddings': grpc.unary_unary_rpc_method_handler(
+                    servicer.GenerateEmbeddings,
+                    request_deserializer=embed__pb2.EmbeddingsRequest.FromString,
+                    response_serializer=embed__pb2.EmbeddingsResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'embed.Embedder', rpc_method_handlers)
@@ -99,3 +116,30 @@ class Embedder(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def GenerateEmbeddings(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/embed.Embedder/GenerateEmbeddings',
+            embed__pb2.EmbeddingsRequest.SerializeToString,
+            embed__pb2.EmbeddingsResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
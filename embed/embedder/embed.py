@@ -1,11 +1,20 @@
+import os
 from sentence_transformers import SentenceTransformer
 
 
+DEFAULT_MODEL = 'sentence-transformers/all-MiniLM-L6-v2'
+
+
 class Embedder:
-    def __init__(self):
-        self.model = SentenceTransformer('sentence-transformers/all-MiniLM-L6-v2')
+    def __init__(self, model_name=None):
+        self.model_name = model_name or os.environ.get('EMBEDDING_MODEL', DEFAULT_MODEL)
+        self.model = SentenceTransformer(self.model_name)
 
 
     def embed(self, text):
         return self.model.encode(text, convert_to_tensor=True)
+
+
+    def embed_batch(self, texts):
+        return self.model.encode(texts, convert_to_tensor=True)
     
\ No newline at end of file
@@ -1,7 +1,7 @@
 from embedder import Embedder
 import grpc
 from concurrent import futures
-from embed_pb2 import EmbeddingResponse
+from embed_pb2 import Embedding, EmbeddingResponse, EmbeddingsResponse
 from embed_pb2_grpc import EmbedderServicer, add_EmbedderServicer_to_server
 import logging
 
@@ -15,7 +15,16 @@ class EmbedderService(EmbedderServicer):
 
     def GenerateEmbedding(self, request, context):
         embedding = self.embedder.embed(request.text)
-        return EmbeddingResponse(embedding=embedding.tolist())
+        return EmbeddingResponse(embedding=embedding.tolist(), model=self.embedder.model_name)
+
+    def GenerateEmbeddings(self, request, context):
+        if len(request.texts) == 0:
+            return EmbeddingsResponse(model=self.embedder.model_name)
+        embeddings = self.embedder.embed_batch(list(request.texts))
+        return EmbeddingsResponse(
+            embeddings=[Embedding(values=embedding.tolist()) for embedding in embeddings],
+            model=self.embedder.model_name
+        )
 
 
 def serve():
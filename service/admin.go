@@ -0,0 +1,198 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+)
+
+// adminToken authenticates the cache administration endpoints, read from
+// ADMIN_TOKEN; the endpoints are not served when it is unset
+func adminToken() string {
+	return getEnvWithDefault("ADMIN_TOKEN", "")
+}
+
+// registerAdminHandlers serves the cache administration, correction and
+// glossary endpoints behind bearer token authentication
+func registerAdminHandlers(mux *http.ServeMux, token string) {
+	mux.HandleFunc("/admin/cache", requireAdmin(token, handleAdminCache))
+	mux.HandleFunc("/admin/cache/stats", requireAdmin(token, handleAdminCacheStats))
+	mux.HandleFunc("/admin/cache/{id}", requireAdmin(token, handleAdminCacheEntry))
+	mux.HandleFunc("/admin/corrections", requireAdmin(token, handleCorrections))
+	mux.HandleFunc("/admin/glossaries", requireAdmin(token, handleGlossaries))
+	mux.HandleFunc("/admin/glossaries/{id}", requireAdmin(token, handleGlossary))
+}
+
+// requireAdmin rejects requests without an "Authorization: Bearer <token>"
+// header matching token
+func requireAdmin(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			writeError(w, r, apiError{Code: codeUnauthorized, Message: "missing or invalid admin token", status: http.StatusUnauthorized, grpcCode: codes.Unauthenticated})
+			return
+		}
+		next(w, r)
+	}
+}
+
+// handleAdminCache handles the /admin/cache endpoint: GET lists entries and
+// DELETE deletes every entry matching the filter
+func handleAdminCache(w http.ResponseWriter, r *http.Request) {
+	defer recoverFromPanic(w, r)
+
+	query := r.URL.Query()
+	filter := cacheFilter{
+		SourceLanguage: query.Get("source_language"),
+		TargetLanguage: query.Get("target_language"),
+		Query:          query.Get("q"),
+		Pattern:        query.Get("pattern"),
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		limit, err := queryInt(query.Get("limit"), 50)
+		if err != nil || limit <= 0 || limit > 500 {
+			writeError(w, r, invalidRequest("limit must be between 1 and 500"))
+			return
+		}
+		offset, err := queryInt(query.Get("offset"), 0)
+		if err != nil || offset < 0 {
+			writeError(w, r, invalidRequest("offset must not be negative"))
+			return
+		}
+
+		rows, err := store.ListRows(r.Context(), filter, limit, offset)
+		if err != nil {
+			writeError(w, r, classifyError(err))
+			return
+		}
+		if rows == nil {
+			rows = []cacheRow{}
+		}
+		writeJSONResponse(w, http.StatusOK, map[string]interface{}{"entries": rows, "limit": limit, "offset": offset})
+
+	case http.MethodDelete:
+		// Deleting the whole cache takes an explicit filter
+		if filter == (cacheFilter{}) {
+			writeError(w, r, invalidRequest("source_language, target_language, q or pattern is required"))
+			return
+		}
+		deleted, err := store.DeleteRows(r.Context(), filter)
+		if err != nil {
+			writeError(w, r, classifyError(err))
+			return
+		}
+		writeJSONResponse(w, http.StatusOK, map[string]int64{"deleted": deleted})
+
+	default:
+		writeError(w, r, methodNotAllowed())
+	}
+}
+
+// handleAdminCacheEntry handles the /admin/cache/{id} endpoint: GET shows an
+// entry, PATCH replaces its target_text, marking it human approved, and
+// DELETE deletes it
+func handleAdminCacheEntry(w http.ResponseWriter, r *http.Request) {
+	defer recoverFromPanic(w, r)
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, r, notFound("cache entry not found"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPatch:
+		var request struct {
+			TargetText string `json:"target_text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || strings.TrimSpace(request.TargetText) == "" {
+			writeError(w, r, invalidRequest("target_text is required"))
+			return
+		}
+		// Entries are cached masked, so the translation must keep every mask
+		// token of the source for the spans and terms to be restored
+		row, found, err := store.GetRow(r.Context(), id)
+		if err != nil {
+			writeError(w, r, classifyError(err))
+			return
+		}
+		if !found {
+			writeError(w, r, notFound("cache entry not found"))
+			return
+		}
+		if !sameMaskTokens(row.SourceText, request.TargetText) {
+			writeError(w, r, invalidRequest("target_text must contain each ⟦n⟧ token of source_text exactly once"))
+			return
+		}
+		found, err = store.UpdateTargetText(r.Context(), id, request.TargetText)
+		if err != nil {
+			writeError(w, r, classifyError(err))
+			return
+		}
+		if !found {
+			writeError(w, r, notFound("cache entry not found"))
+			return
+		}
+	case http.MethodDelete:
+		found, err := store.DeleteRow(r.Context(), id)
+		if err != nil {
+			writeError(w, r, classifyError(err))
+			return
+		}
+		if !found {
+			writeError(w, r, notFound("cache entry not found"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		writeError(w, r, methodNotAllowed())
+		return
+	}
+
+	row, found, err := store.GetRow(r.Context(), id)
+	if err != nil {
+		writeError(w, r, classifyError(err))
+		return
+	}
+	if !found {
+		writeError(w, r, notFound("cache entry not found"))
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, row)
+}
+
+// handleAdminCacheStats handles the /admin/cache/stats endpoint
+func handleAdminCacheStats(w http.ResponseWriter, r *http.Request) {
+	defer recoverFromPanic(w, r)
+
+	if r.Method != http.MethodGet {
+		writeError(w, r, methodNotAllowed())
+		return
+	}
+
+	stats, err := store.PairStats(r.Context())
+	if err != nil {
+		writeError(w, r, classifyError(err))
+		return
+	}
+	if stats == nil {
+		stats = []pairStats{}
+	}
+	writeJSONResponse(w, http.StatusOK, map[string][]pairStats{"pairs": stats})
+}
+
+// queryInt parses an optional integer query parameter
+func queryInt(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// batchItem is a single independently translated item of a batch request
+type batchItem struct {
+	ID             string `json:"id"`
+	Text           string `json:"text"`
+	SourceLanguage string `json:"source_language"`
+	TargetLanguage string `json:"target_language"`
+	Tenant         string `json:"tenant"`
+}
+
+// batchResult is the outcome of translating a single batch item
+type batchResult struct {
+	ID          string `json:"id"`
+	Translation string `json:"translation,omitempty"`
+	Error       string `json:"error,omitempty"`
+	ErrorCode   string `json:"error_code,omitempty"`
+	Retryable   bool   `json:"retryable,omitempty"`
+
+	Flagged []flaggedSegment `json:"flagged,omitempty"`
+
+	// Set when the item's source language was omitted and detected
+	DetectedLanguage    string  `json:"detected_language,omitempty"`
+	DetectionConfidence float64 `json:"detection_confidence,omitempty"`
+}
+
+// maxBatchSize returns the maximum number of items accepted in one batch request
+func maxBatchSize() int {
+	size, err := strconv.Atoi(getEnvWithDefault("MAX_BATCH_SIZE", "500"))
+	if err != nil || size <= 0 {
+		return 500
+	}
+	return size
+}
+
+// handleTranslateBatch handles the /translate/batch endpoint
+func handleTranslateBatch(w http.ResponseWriter, r *http.Request) {
+	defer recoverFromPanic(w, r)
+
+	if r.Method != http.MethodPost {
+		writeError(w, r, methodNotAllowed())
+		return
+	}
+
+	var request struct {
+		Items []batchItem `json:"items"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.Items) == 0 {
+		writeError(w, r, invalidRequest("invalid or missing items in request body"))
+		return
+	}
+	if len(request.Items) > maxBatchSize() {
+		writeError(w, r, batchTooLarge(maxBatchSize()))
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string][]batchResult{"results": processBatch(r.Context(), request.Items)})
+}
+
+// processBatch translates each item independently, TRANSLATION_CONCURRENCY
+// at a time, so that a failing item does not fail the whole batch; results
+// are returned in request order
+func processBatch(ctx context.Context, items []batchItem) []batchResult {
+	results := make([]batchResult, len(items))
+	processed := make([]bool, len(items))
+	err := forEachConcurrently(ctx, len(items), translationConcurrency(), func(ctx context.Context, i int) error {
+		results[i] = processBatchItem(ctx, items[i])
+		processed[i] = true
+		return nil
+	})
+	if err != nil {
+		// Items not started before the request was canceled
+		for i := range results {
+			if !processed[i] {
+				results[i].ID = items[i].ID
+				results[i].setError(classifyError(err))
+			}
+		}
+	}
+	return results
+}
+
+// processBatchItem translates a single batch item
+func processBatchItem(ctx context.Context, item batchItem) batchResult {
+	result := batchResult{ID: item.ID}
+	if item.Text == "" || item.TargetLanguage == "" {
+		result.setError(invalidRequest("missing text or target_language"))
+		return result
+	}
+
+	translation, err := processTranslation(ctx, item.Text, item.SourceLanguage, item.TargetLanguage, translationOptions{Tenant: item.Tenant})
+	if err != nil {
+		e := classifyError(err)
+		if e.cause != nil {
+			log.Printf("Error processing batch item %q: %v", item.ID, err)
+		}
+		result.setError(e)
+		return result
+	}
+	result.Translation = translation.Translation
+	result.Flagged = translation.Flagged
+	result.DetectedLanguage = translation.DetectedLanguage
+	result.DetectionConfidence = translation.DetectionConfidence
+	return result
+}
+
+// setError records a failed item
+func (r *batchResult) setError(e apiError) {
+	r.Error = e.Message
+	r.ErrorCode = e.Code
+	r.Retryable = e.Retryable
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// correctionRequest is a translation approved by a translator, replacing the
+// cached translation of the same source sentence
+type correctionRequest struct {
+	SourceLanguage string `json:"source_language"`
+	TargetLanguage string `json:"target_language"`
+	SourceText     string `json:"source_text"`
+	TargetText     string `json:"target_text"`
+	Tenant         string `json:"tenant"`
+	Source         string `json:"source"`   // originHuman, the default, or originImported
+	Priority       *int   `json:"priority"` // Defaults to 1
+}
+
+// handleCorrections handles the /admin/corrections endpoint, pinning the
+// translation of a source sentence for a language pair
+func handleCorrections(w http.ResponseWriter, r *http.Request) {
+	defer recoverFromPanic(w, r)
+
+	if r.Method != http.MethodPost {
+		writeError(w, r, methodNotAllowed())
+		return
+	}
+
+	var request correctionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, r, invalidRequest("invalid request payload"))
+		return
+	}
+	if request.SourceLanguage == "" || request.TargetLanguage == "" || isAutoLanguage(request.SourceLanguage) {
+		writeError(w, r, invalidRequest("source_language and target_language are required"))
+		return
+	}
+	if strings.TrimSpace(request.TargetText) == "" {
+		writeError(w, r, invalidRequest("target_text is required"))
+		return
+	}
+	if request.Source == "" {
+		request.Source = originHuman
+	}
+	if request.Source != originHuman && request.Source != originImported {
+		writeError(w, r, invalidRequest("source must be human or imported"))
+		return
+	}
+	priority := 1
+	if request.Priority != nil {
+		priority = *request.Priority
+	}
+	if priority < 0 {
+		writeError(w, r, invalidRequest("priority must not be negative"))
+		return
+	}
+
+	// Entries are cached per sentence, so a correction covers exactly one
+	segments, masked, protected := segmentMasked(request.SourceLanguage, request.SourceText)
+	if len(segments) != 1 {
+		writeError(w, r, invalidRequest("source_text must be a single sentence"))
+		return
+	}
+	targetText := strings.TrimSpace(request.TargetText)
+
+	// Sentences are cached with their do-not-translate spans and glossary
+	// terms masked, so the correction is too, and its translation must keep
+	// the spans and use the mandated terms
+	glossary, err := findGlossary(r.Context(), request.Tenant, request.SourceLanguage, request.TargetLanguage)
+	if err != nil {
+		writeError(w, r, classifyError(err))
+		return
+	}
+	var glossaryID int64
+	if glossary != nil {
+		glossaryID = glossary.ID
+	}
+	sourceText, spans, _ := maskSentence(masked[0], protected[0], glossary)
+	if targetText, err = maskTranslation(targetText, spans); err != nil {
+		writeError(w, r, invalidRequest(err.Error()))
+		return
+	}
+
+	embeddings, model, err := getEmbeddings(r.Context(), []string{sourceText})
+	if err != nil {
+		writeError(w, r, classifyError(err))
+		return
+	}
+	id, err := store.SaveOverride(r.Context(), cacheRecord{
+		SourceLanguage: request.SourceLanguage,
+		TargetLanguage: request.TargetLanguage,
+		SourceText:     sourceText,
+		TargetText:     targetText,
+		SourceHash:     sourceHash(sourceText),
+		Embedding:      embeddings[0],
+		EmbeddingModel: model,
+		Origin:         request.Source,
+		Priority:       priority,
+		GlossaryID:     glossaryID,
+	})
+	if err != nil {
+		writeError(w, r, classifyError(err))
+		return
+	}
+
+	row, found, err := store.GetRow(r.Context(), id)
+	if err != nil {
+		writeError(w, r, classifyError(err))
+		return
+	}
+	if !found {
+		writeError(w, r, notFound("cache entry not found"))
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, row)
+}
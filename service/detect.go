@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"service/langdetect"
+)
+
+// errUndetectedLanguage is returned when the source language was omitted and
+// could not be detected from the text
+var errUndetectedLanguage = errors.New("source language could not be detected")
+
+// isAutoLanguage reports whether the source language should be detected
+func isAutoLanguage(lang string) bool {
+	lang = strings.TrimSpace(lang)
+	return lang == "" || strings.EqualFold(lang, "auto")
+}
+
+// minDetectionConfidence returns the confidence below which a detected
+// language is not trusted, read from DETECTION_MIN_CONFIDENCE
+func minDetectionConfidence() float64 {
+	confidence, err := strconv.ParseFloat(getEnvWithDefault("DETECTION_MIN_CONFIDENCE", "0.6"), 64)
+	if err != nil || confidence < 0 || confidence > 1 {
+		return 0.6
+	}
+	return confidence
+}
+
+// detectSourceLanguage detects the language of text; guesses below the
+// minimum confidence are rejected rather than used as the cache partition
+func detectSourceLanguage(text string) (langdetect.Result, error) {
+	detected, ok := langdetect.Detect(text)
+	if !ok {
+		return detected, errUndetectedLanguage
+	}
+	if detected.Confidence < minDetectionConfidence() {
+		return detected, fmt.Errorf("%w: %s guessed with confidence %.2f", errUndetectedLanguage, detected.Language, detected.Confidence)
+	}
+	return detected, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dictionaryTranslator is a Translator serving fixed translations of whole
+// sentences, for pairs where only approved wording may be used
+type dictionaryTranslator struct {
+	entries map[string]map[string]string // Keyed by pairKey, then normalized source text
+}
+
+// loadDictionaryTranslator reads a JSON file mapping language pairs to
+// source and target sentences, e.g. {"en:es": {"Hello": "Hola"}}
+func loadDictionaryTranslator(path string) (*dictionaryTranslator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading dictionary: %w", err)
+	}
+	var raw map[string]map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing dictionary: %w", err)
+	}
+
+	t := &dictionaryTranslator{entries: make(map[string]map[string]string, len(raw))}
+	for pair, translations := range raw {
+		sourceLang, targetLang, ok := strings.Cut(pair, ":")
+		if !ok || sourceLang == "" || targetLang == "" {
+			return nil, fmt.Errorf("invalid dictionary language pair %q", pair)
+		}
+		entries := make(map[string]string, len(translations))
+		for source, target := range translations {
+			entries[dictionaryKey(source)] = target
+		}
+		t.entries[pairKey(sourceLang, targetLang)] = entries
+	}
+	return t, nil
+}
+
+// dictionaryKey normalizes a sentence for lookup, ignoring case and
+// whitespace differences
+func dictionaryKey(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+func (t *dictionaryTranslator) Name() string {
+	return "dictionary"
+}
+
+func (t *dictionaryTranslator) Translate(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	entries := t.entries[pairKey(sourceLang, targetLang)]
+	translations := make([]string, len(texts))
+	for i, text := range texts {
+		translation, ok := entries[dictionaryKey(text)]
+		if !ok {
+			return nil, &backendError{service: "translation", err: status.Errorf(codes.NotFound, "no dictionary entry for %q", text)}
+		}
+		translations[i] = translation
+	}
+	return translations, nil
+}
+
+func (t *dictionaryTranslator) Languages(ctx context.Context) ([]languagePair, error) {
+	pairs := make([]languagePair, 0, len(t.entries))
+	for key := range t.entries {
+		sourceLang, targetLang, _ := strings.Cut(key, ":")
+		pairs = append(pairs, languagePair{SourceLanguage: sourceLang, TargetLanguage: targetLang})
+	}
+	return pairs, nil
+}
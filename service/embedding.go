@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	embedpb "service/embeddingapi/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Embedder generates sentence embeddings for the similarity search;
+// implementations must be safe for concurrent use
+type Embedder interface {
+	// Name identifies the backend in configuration
+	Name() string
+	// Embed returns one embedding per text, in order, and the id of the
+	// model that produced them
+	Embed(ctx context.Context, texts []string) ([][]float32, string, error)
+}
+
+// newEmbedder creates an embedding backend by name from its environment
+// variables
+func newEmbedder(name string) (Embedder, error) {
+	switch name {
+	case "grpc":
+		return newGrpcEmbedder(getEnv("EMBEDDING_URL"))
+	case "hashing":
+		return newHashingEmbedder(), nil
+	}
+	return nil, fmt.Errorf("unknown embedding backend %q", name)
+}
+
+// closeEmbedder releases the embedder's connections, if it holds any
+func closeEmbedder(e Embedder) {
+	if closer, ok := e.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// grpcEmbedder is an Embedder backed by the embedder.Embedder gRPC service,
+// micro-batching concurrent calls
+type grpcEmbedder struct {
+	*embeddingBatcher
+	conn *grpc.ClientConn
+}
+
+// newGrpcEmbedder creates a client for the embedding service at url
+func newGrpcEmbedder(url string) (*grpcEmbedder, error) {
+	conn, err := grpc.NewClient(url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to embedding service: %w", err)
+	}
+	window, size := embeddingBatchConfig()
+	return &grpcEmbedder{
+		embeddingBatcher: newEmbeddingBatcher(embedpb.NewEmbedderClient(conn), window, size),
+		conn:             conn,
+	}, nil
+}
+
+func (e *grpcEmbedder) Name() string {
+	return "grpc"
+}
+
+func (e *grpcEmbedder) Close() error {
+	return e.conn.Close()
+}
+
+// defaultEmbeddingTimeout bounds embedding calls made for callers without a
+// deadline
+const defaultEmbeddingTimeout = 10 * time.Second
+
+// embeddingBatcher groups embedding requests arriving within a short window,
+// including those of concurrent HTTP requests, into one GenerateEmbeddings call
+type embeddingBatcher struct {
+	client   embedpb.EmbedderClient
+	window   time.Duration
+	maxTexts int
+	pending  chan *embeddingCall
+}
+
+// embeddingCall is a caller's share of a batched GenerateEmbeddings call
+type embeddingCall struct {
+	ctx        context.Context
+	texts      []string
+	embeddings [][]float32
+	model      string
+	err        error
+	done       chan struct{}
+}
+
+// newEmbeddingBatcher starts a batcher that waits up to window after the
+// first pending request, or until maxTexts texts are pending, before calling
+// the embedding service; no call carries more than maxTexts texts
+func newEmbeddingBatcher(client embedpb.EmbedderClient, window time.Duration, maxTexts int) *embeddingBatcher {
+	b := &embeddingBatcher{
+		client:   client,
+		window:   window,
+		maxTexts: maxTexts,
+		pending:  make(chan *embeddingCall),
+	}
+	go b.run()
+	return b
+}
+
+// embeddingBatchConfig reads the micro-batching window and size from
+// EMBEDDING_BATCH_WINDOW and EMBEDDING_BATCH_SIZE
+func embeddingBatchConfig() (time.Duration, int) {
+	window, err := time.ParseDuration(getEnvWithDefault("EMBEDDING_BATCH_WINDOW", "5ms"))
+	if err != nil || window < 0 {
+		window = 5 * time.Millisecond
+	}
+	size, err := strconv.Atoi(getEnvWithDefault("EMBEDDING_BATCH_SIZE", "64"))
+	if err != nil || size <= 0 {
+		size = 64
+	}
+	return window, size
+}
+
+// Embed returns one embedding per text, in order, and the model id
+// reported by the embedding service; texts beyond the batch size are split
+// across calls
+func (b *embeddingBatcher) Embed(ctx context.Context, texts []string) ([][]float32, string, error) {
+	if len(texts) == 0 {
+		return nil, "", nil
+	}
+
+	var calls []*embeddingCall
+	for start := 0; start < len(texts); start += b.maxTexts {
+		end := min(start+b.maxTexts, len(texts))
+		call := &embeddingCall{ctx: ctx, texts: texts[start:end], done: make(chan struct{})}
+		select {
+		case b.pending <- call:
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		}
+		calls = append(calls, call)
+	}
+
+	embeddings := make([][]float32, 0, len(texts))
+	var model string
+	for _, call := range calls {
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		}
+		if call.err != nil {
+			return nil, "", call.err
+		}
+		if model != "" && call.model != model {
+			return nil, "", fmt.Errorf("embedding service switched model from %s to %s", model, call.model)
+		}
+		model = call.model
+		embeddings = append(embeddings, call.embeddings...)
+	}
+	return embeddings, model, nil
+}
+
+// run collects pending calls into batches of at most maxTexts texts and
+// flushes each in the background
+func (b *embeddingBatcher) run() {
+	var next *embeddingCall
+	for {
+		first := next
+		if first == nil {
+			var ok bool
+			if first, ok = <-b.pending; !ok {
+				return
+			}
+		}
+		next = nil
+		batch := []*embeddingCall{first}
+		size := len(first.texts)
+
+		timer := time.NewTimer(b.window)
+	collect:
+		for size < b.maxTexts {
+			select {
+			case call := <-b.pending:
+				if size+len(call.texts) > b.maxTexts {
+					// The call starts the next batch instead
+					next = call
+					break collect
+				}
+				batch = append(batch, call)
+				size += len(call.texts)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		go b.flush(batch)
+	}
+}
+
+// batchContext returns the context of the call made on behalf of a batch,
+// which is canceled once every caller has given up and carries the latest of
+// their deadlines, defaulting to defaultEmbeddingTimeout for callers without
+// one
+func batchContext(batch []*embeddingCall) (context.Context, context.CancelFunc) {
+	var latest time.Time
+	for _, call := range batch {
+		deadline, ok := call.ctx.Deadline()
+		if !ok {
+			deadline = time.Now().Add(defaultEmbeddingTimeout)
+		}
+		if deadline.After(latest) {
+			latest = deadline
+		}
+	}
+
+	base, cancelBase := context.WithCancel(context.Background())
+	ctx, cancelDeadline := context.WithDeadline(base, latest)
+
+	var remaining atomic.Int32
+	remaining.Store(int32(len(batch)))
+	stops := make([]func() bool, len(batch))
+	for i, call := range batch {
+		stops[i] = context.AfterFunc(call.ctx, func() {
+			if remaining.Add(-1) == 0 {
+				cancelBase()
+			}
+		})
+	}
+	return ctx, func() {
+		for _, stop := range stops {
+			stop()
+		}
+		cancelDeadline()
+		cancelBase()
+	}
+}
+
+// flush sends the texts of a batch in one call and distributes the results
+func (b *embeddingBatcher) flush(batch []*embeddingCall) {
+	var texts []string
+	for _, call := range batch {
+		texts = append(texts, call.texts...)
+	}
+
+	ctx, cancel := batchContext(batch)
+	defer cancel()
+
+	res, err := b.client.GenerateEmbeddings(ctx, &embedpb.EmbeddingsRequest{Texts: texts})
+	if err != nil {
+		err = &backendError{service: "embedding", err: err}
+	} else if len(res.Embeddings) != len(texts) {
+		err = fmt.Errorf("embedding service returned %d embeddings for %d texts", len(res.Embeddings), len(texts))
+	} else if res.Model == "" {
+		err = fmt.Errorf("embedding service did not report its model")
+	}
+
+	offset := 0
+	for _, call := range batch {
+		if err != nil {
+			call.err = err
+		} else {
+			call.model = res.Model
+			call.embeddings = make([][]float32, len(call.texts))
+			for i := range call.texts {
+				call.embeddings[i] = res.Embeddings[offset+i].Values
+			}
+		}
+		offset += len(call.texts)
+		close(call.done)
+	}
+}
+
+// getEmbeddings fetches the embeddings for the given texts in one call,
+// returning them with the id of the model that produced them
+func getEmbeddings(ctx context.Context, texts []string) ([][]float32, string, error) {
+	if len(texts) == 0 {
+		return nil, "", nil
+	}
+	embeddings, model, err := embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(embeddings) != len(texts) {
+		return nil, "", fmt.Errorf("%s embedder returned %d embeddings for %d texts", embedder.Name(), len(embeddings), len(texts))
+	}
+	return embeddings, model, nil
+}
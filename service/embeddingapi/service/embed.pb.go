@@ -70,6 +70,7 @@ func (x *EmbeddingRequest) GetText() string {
 type EmbeddingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Embedding     []float32              `protobuf:"fixed32,1,rep,packed,name=embedding,proto3" json:"embedding,omitempty"` // The embedding vector.
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`                  // The model that produced the embedding.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -111,17 +112,178 @@ func (x *EmbeddingResponse) GetEmbedding() []float32 {
 	return nil
 }
 
+func (x *EmbeddingResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+// The request message containing the texts to generate embeddings for.
+type EmbeddingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Texts         []string               `protobuf:"bytes,1,rep,name=texts,proto3" json:"texts,omitempty"` // The texts to embed.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbeddingsRequest) Reset() {
+	*x = EmbeddingsRequest{}
+	mi := &file_embed_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingsRequest) ProtoMessage() {}
+
+func (x *EmbeddingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_embed_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingsRequest.ProtoReflect.Descriptor instead.
+func (*EmbeddingsRequest) Descriptor() ([]byte, []int) {
+	return file_embed_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EmbeddingsRequest) GetTexts() []string {
+	if x != nil {
+		return x.Texts
+	}
+	return nil
+}
+
+// A single embedding vector.
+type Embedding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []float32              `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"` // The embedding vector.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Embedding) Reset() {
+	*x = Embedding{}
+	mi := &file_embed_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Embedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Embedding) ProtoMessage() {}
+
+func (x *Embedding) ProtoReflect() protoreflect.Message {
+	mi := &file_embed_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Embedding.ProtoReflect.Descriptor instead.
+func (*Embedding) Descriptor() ([]byte, []int) {
+	return file_embed_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Embedding) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// The response message containing one embedding per text, in request order.
+type EmbeddingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Embeddings    []*Embedding           `protobuf:"bytes,1,rep,name=embeddings,proto3" json:"embeddings,omitempty"` // The embedding vectors.
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`           // The model that produced the embeddings.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbeddingsResponse) Reset() {
+	*x = EmbeddingsResponse{}
+	mi := &file_embed_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingsResponse) ProtoMessage() {}
+
+func (x *EmbeddingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_embed_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingsResponse.ProtoReflect.Descriptor instead.
+func (*EmbeddingsResponse) Descriptor() ([]byte, []int) {
+	return file_embed_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EmbeddingsResponse) GetEmbeddings() []*Embedding {
+	if x != nil {
+		return x.Embeddings
+	}
+	return nil
+}
+
+func (x *EmbeddingsResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
 var File_embed_proto protoreflect.FileDescriptor
 
 const file_embed_proto_rawDesc = "" +
 	"\n" +
 	"\vembed.proto\x12\x05embed\"&\n" +
 	"\x10EmbeddingRequest\x12\x12\n" +
-	"\x04text\x18\x01 \x01(\tR\x04text\"1\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"G\n" +
 	"\x11EmbeddingResponse\x12\x1c\n" +
-	"\tembedding\x18\x01 \x03(\x02R\tembedding2R\n" +
+	"\tembedding\x18\x01 \x03(\x02R\tembedding\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\")\n" +
+	"\x11EmbeddingsRequest\x12\x14\n" +
+	"\x05texts\x18\x01 \x03(\tR\x05texts\"#\n" +
+	"\tEmbedding\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\x02R\x06values\"\\\n" +
+	"\x12EmbeddingsResponse\x120\n" +
+	"\n" +
+	"embeddings\x18\x01 \x03(\v2\x10.embed.EmbeddingR\n" +
+	"embeddings\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model2\x9d\x01\n" +
 	"\bEmbedder\x12F\n" +
-	"\x11GenerateEmbedding\x12\x17.embed.EmbeddingRequest\x1a\x18.embed.EmbeddingResponseB\x1cZ\x1aembeddingapi/service;embedb\x06proto3"
+	"\x11GenerateEmbedding\x12\x17.embed.EmbeddingRequest\x1a\x18.embed.EmbeddingResponse\x12I\n" +
+	"\x12GenerateEmbeddings\x12\x18.embed.EmbeddingsRequest\x1a\x19.embed.EmbeddingsResponseB\x1cZ\x1aembeddingapi/service;embedb\x06proto3"
 
 var (
 	file_embed_proto_rawDescOnce sync.Once
@@ -135,19 +297,25 @@ func file_embed_proto_rawDescGZIP() []byte {
 	return file_embed_proto_rawDescData
 }
 
-var file_embed_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_embed_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_embed_proto_goTypes = []any{
-	(*EmbeddingRequest)(nil),  // 0: embed.EmbeddingRequest
-	(*EmbeddingResponse)(nil), // 1: embed.EmbeddingResponse
+	(*EmbeddingRequest)(nil),   // 0: embed.EmbeddingRequest
+	(*EmbeddingResponse)(nil),  // 1: embed.EmbeddingResponse
+	(*EmbeddingsRequest)(nil),  // 2: embed.EmbeddingsRequest
+	(*Embedding)(nil),          // 3: embed.Embedding
+	(*EmbeddingsResponse)(nil), // 4: embed.EmbeddingsResponse
 }
 var file_embed_proto_depIdxs = []int32{
-	0, // 0: embed.Embedder.GenerateEmbedding:input_type -> embed.EmbeddingRequest
-	1, // 1: embed.Embedder.GenerateEmbedding:output_type -> embed.EmbeddingResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3, // 0: embed.EmbeddingsResponse.embeddings:type_name -> embed.Embedding
+	0, // 1: embed.Embedder.GenerateEmbedding:input_type -> embed.EmbeddingRequest
+	2, // 2: embed.Embedder.GenerateEmbeddings:input_type -> embed.EmbeddingsRequest
+	1, // 3: embed.Embedder.GenerateEmbedding:output_type -> embed.EmbeddingResponse
+	4, // 4: embed.Embedder.GenerateEmbeddings:output_type -> embed.EmbeddingsResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_embed_proto_init() }
@@ -161,7 +329,7 @@ func file_embed_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_embed_proto_rawDesc), len(file_embed_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
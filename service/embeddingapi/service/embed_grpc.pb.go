@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Embedder_GenerateEmbedding_FullMethodName = "/embed.Embedder/GenerateEmbedding"
+	Embedder_GenerateEmbedding_FullMethodName  = "/embed.Embedder/GenerateEmbedding"
+	Embedder_GenerateEmbeddings_FullMethodName = "/embed.Embedder/GenerateEmbeddings"
 )
 
 // EmbedderClient is the client API for Embedder service.
@@ -30,6 +31,8 @@ const (
 type EmbedderClient interface {
 	// Generates an embedding for the given text.
 	GenerateEmbedding(ctx context.Context, in *EmbeddingRequest, opts ...grpc.CallOption) (*EmbeddingResponse, error)
+	// Generates embeddings for many texts in a single model call.
+	GenerateEmbeddings(ctx context.Context, in *EmbeddingsRequest, opts ...grpc.CallOption) (*EmbeddingsResponse, error)
 }
 
 type embedderClient struct {
@@ -50,6 +53,16 @@ func (c *embedderClient) GenerateEmbedding(ctx context.Context, in *EmbeddingReq
 	return out, nil
 }
 
+func (c *embedderClient) GenerateEmbeddings(ctx context.Context, in *EmbeddingsRequest, opts ...grpc.CallOption) (*EmbeddingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmbeddingsResponse)
+	err := c.cc.Invoke(ctx, Embedder_GenerateEmbeddings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EmbedderServer is the server API for Embedder service.
 // All implementations must embed UnimplementedEmbedderServer
 // for forward compatibility.
@@ -58,6 +71,8 @@ func (c *embedderClient) GenerateEmbedding(ctx context.Context, in *EmbeddingReq
 type EmbedderServer interface {
 	// Generates an embedding for the given text.
 	GenerateEmbedding(context.Context, *EmbeddingRequest) (*EmbeddingResponse, error)
+	// Generates embeddings for many texts in a single model call.
+	GenerateEmbeddings(context.Context, *EmbeddingsRequest) (*EmbeddingsResponse, error)
 	mustEmbedUnimplementedEmbedderServer()
 }
 
@@ -71,6 +86,9 @@ type UnimplementedEmbedderServer struct{}
 func (UnimplementedEmbedderServer) GenerateEmbedding(context.Context, *EmbeddingRequest) (*EmbeddingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateEmbedding not implemented")
 }
+func (UnimplementedEmbedderServer) GenerateEmbeddings(context.Context, *EmbeddingsRequest) (*EmbeddingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateEmbeddings not implemented")
+}
 func (UnimplementedEmbedderServer) mustEmbedUnimplementedEmbedderServer() {}
 func (UnimplementedEmbedderServer) testEmbeddedByValue()                  {}
 
@@ -110,6 +128,24 @@ func _Embedder_GenerateEmbedding_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Embedder_GenerateEmbeddings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbeddingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmbedderServer).GenerateEmbeddings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Embedder_GenerateEmbeddings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmbedderServer).GenerateEmbeddings(ctx, req.(*EmbeddingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Embedder_ServiceDesc is the grpc.ServiceDesc for Embedder service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -121,6 +157,10 @@ var Embedder_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateEmbedding",
 			Handler:    _Embedder_GenerateEmbedding_Handler,
 		},
+		{
+			MethodName: "GenerateEmbeddings",
+			Handler:    _Embedder_GenerateEmbeddings_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "embed.proto",
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Stable error codes reported to clients
+const (
+	codeInvalidRequest      = "invalid_request"
+	codeUnauthorized        = "unauthorized"
+	codeNotFound            = "not_found"
+	codeConflict            = "conflict"
+	codeMethodNotAllowed    = "method_not_allowed"
+	codeBatchTooLarge       = "batch_too_large"
+	codeUndetectedLanguage  = "undetected_language"
+	codeUnsupportedPair     = "unsupported_language_pair"
+	codeBackendUnavailable  = "backend_unavailable"
+	codeBackendTimeout      = "backend_timeout"
+	codeBackendError        = "backend_error"
+	codeDatabaseUnavailable = "database_unavailable"
+	codeDatabaseError       = "database_error"
+	codeCanceled            = "canceled"
+	codeInternal            = "internal"
+)
+
+// errorDomain identifies this service in gRPC ErrorInfo details
+const errorDomain = "translationsapi"
+
+// statusClientClosedRequest is the conventional status for requests the
+// client abandoned
+const statusClientClosedRequest = 499
+
+// apiError is a failure reported to clients as a JSON envelope or a gRPC
+// status with details
+type apiError struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"`
+	RequestID string `json:"request_id"`
+
+	status   int        // HTTP status code
+	grpcCode codes.Code // gRPC status code
+	cause    error      // Underlying error, logged for server side failures
+}
+
+// backendError marks an error returned by the translation or embedding
+// service so it can be reported by service name
+type backendError struct {
+	service string
+	err     error
+}
+
+func (e *backendError) Error() string {
+	return fmt.Sprintf("error calling %s service: %v", e.service, e.err)
+}
+
+func (e *backendError) Unwrap() error {
+	return e.err
+}
+
+// invalidRequest returns an apiError for a malformed request
+func invalidRequest(message string) apiError {
+	return apiError{Code: codeInvalidRequest, Message: message, status: http.StatusBadRequest, grpcCode: codes.InvalidArgument}
+}
+
+// notFound returns an apiError for a missing resource
+func notFound(message string) apiError {
+	return apiError{Code: codeNotFound, Message: message, status: http.StatusNotFound, grpcCode: codes.NotFound}
+}
+
+// methodNotAllowed returns an apiError for an unsupported HTTP method
+func methodNotAllowed() apiError {
+	return apiError{Code: codeMethodNotAllowed, Message: "method not allowed", status: http.StatusMethodNotAllowed, grpcCode: codes.Unimplemented}
+}
+
+// batchTooLarge returns an apiError for a batch over MAX_BATCH_SIZE
+func batchTooLarge(limit int) apiError {
+	return apiError{
+		Code:     codeBatchTooLarge,
+		Message:  fmt.Sprintf("batch exceeds maximum of %d items", limit),
+		status:   http.StatusRequestEntityTooLarge,
+		grpcCode: codes.InvalidArgument,
+	}
+}
+
+// internalError returns an apiError for an unexpected failure
+func internalError(cause error) apiError {
+	return apiError{Code: codeInternal, Message: "internal error", status: http.StatusInternalServerError, grpcCode: codes.Internal, cause: cause}
+}
+
+// classifyError maps an error from the translation pipeline to an apiError
+func classifyError(err error) apiError {
+	switch {
+	case errors.Is(err, errUndetectedLanguage):
+		return apiError{Code: codeUndetectedLanguage, Message: "could not detect source_language, please specify it", status: http.StatusBadRequest, grpcCode: codes.InvalidArgument}
+	case errors.Is(err, errUnsupportedPair):
+		return apiError{Code: codeUnsupportedPair, Message: err.Error(), status: http.StatusBadRequest, grpcCode: codes.InvalidArgument}
+	}
+
+	var backend *backendError
+	if errors.As(err, &backend) {
+		return classifyBackendError(backend)
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return apiError{Code: codeCanceled, Message: "request canceled", status: statusClientClosedRequest, grpcCode: codes.Canceled}
+	case errors.Is(err, context.DeadlineExceeded):
+		return apiError{Code: codeBackendTimeout, Message: "request timed out", Retryable: true, status: http.StatusGatewayTimeout, grpcCode: codes.DeadlineExceeded, cause: err}
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Connection exceptions, rollbacks, insufficient resources and
+		// operator intervention are transient
+		if class := pgErr.Code[:min(2, len(pgErr.Code))]; class == "08" || class == "40" || class == "53" || class == "57" {
+			return databaseUnavailable(err)
+		}
+		return apiError{Code: codeDatabaseError, Message: "database error", status: http.StatusInternalServerError, grpcCode: codes.Internal, cause: err}
+	}
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) || pgconn.Timeout(err) {
+		return databaseUnavailable(err)
+	}
+
+	return internalError(err)
+}
+
+// classifyBackendError maps the gRPC status of a backend call to an apiError
+func classifyBackendError(err *backendError) apiError {
+	switch status.Code(err.err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return apiError{Code: codeBackendUnavailable, Message: err.service + " service unavailable", Retryable: true, status: http.StatusServiceUnavailable, grpcCode: codes.Unavailable, cause: err}
+	case codes.DeadlineExceeded:
+		return apiError{Code: codeBackendTimeout, Message: err.service + " service timed out", Retryable: true, status: http.StatusGatewayTimeout, grpcCode: codes.DeadlineExceeded, cause: err}
+	case codes.Canceled:
+		return apiError{Code: codeCanceled, Message: "request canceled", status: statusClientClosedRequest, grpcCode: codes.Canceled}
+	}
+	return apiError{Code: codeBackendError, Message: err.service + " service failed", status: http.StatusBadGateway, grpcCode: codes.Internal, cause: err}
+}
+
+// databaseUnavailable returns an apiError for a transient database failure
+func databaseUnavailable(cause error) apiError {
+	return apiError{Code: codeDatabaseUnavailable, Message: "database unavailable", Retryable: true, status: http.StatusServiceUnavailable, grpcCode: codes.Unavailable, cause: cause}
+}
+
+// writeError writes e as a JSON error envelope, echoing or assigning the
+// request id
+func writeError(w http.ResponseWriter, r *http.Request, e apiError) {
+	e.RequestID = requestID(r.Header.Get("X-Request-ID"))
+	e.log()
+	w.Header().Set("X-Request-ID", e.RequestID)
+	writeJSONResponse(w, e.status, map[string]apiError{"error": e})
+}
+
+// grpcError returns e as a gRPC status carrying ErrorInfo and RequestInfo
+// details; the request id is taken from the x-request-id metadata if set
+func (e apiError) grpcError(ctx context.Context) error {
+	var incoming string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-request-id"); len(values) > 0 {
+			incoming = values[0]
+		}
+	}
+	e.RequestID = requestID(incoming)
+	e.log()
+
+	st, err := status.New(e.grpcCode, e.Message).WithDetails(
+		&errdetails.ErrorInfo{
+			Reason:   e.Code,
+			Domain:   errorDomain,
+			Metadata: map[string]string{"retryable": strconv.FormatBool(e.Retryable)},
+		},
+		&errdetails.RequestInfo{RequestId: e.RequestID},
+	)
+	if err != nil {
+		log.Printf("Error attaching status details: %v", err)
+		return status.Error(e.grpcCode, e.Message)
+	}
+	return st.Err()
+}
+
+// log records server side failures with their request id
+func (e apiError) log() {
+	if e.cause != nil {
+		log.Printf("Request %s failed with %s: %v", e.RequestID, e.Code, e.cause)
+	}
+}
+
+// requestID returns the client supplied id, or a new random one if it is
+// unset or implausibly long
+func requestID(incoming string) string {
+	if id := strings.TrimSpace(incoming); id != "" && len(id) <= 128 {
+		return id
+	}
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cacheEvictionMetrics counts deleted cache entries by reason, exposed on
+// /debug/vars
+var cacheEvictionMetrics = expvar.NewMap("cache_eviction")
+
+// Eviction orders for pairs over their row limit
+const (
+	evictLRU = "lru" // Least recently hit or created first
+	evictLFU = "lfu" // Fewest hits first, then least recently used
+)
+
+// evictionPolicy holds the cache retention limits; zero values are unlimited
+type evictionPolicy struct {
+	TTL         time.Duration
+	MaxRows     int64
+	PairTTL     map[string]time.Duration // Keyed by pairKey
+	PairMaxRows map[string]int64         // Keyed by pairKey
+	Order       string                   // evictLRU or evictLFU
+	Interval    time.Duration
+	BatchSize   int
+}
+
+// loadEvictionPolicy reads the retention limits from CACHE_TTL and
+// CACHE_MAX_ROWS, their per language pair overrides CACHE_TTL_BY_PAIR and
+// CACHE_MAX_ROWS_BY_PAIR, formatted as "en:es=720h,en:de=168h", and the
+// eviction settings CACHE_EVICTION_POLICY, CACHE_EVICTION_INTERVAL and
+// CACHE_EVICTION_BATCH_SIZE
+func loadEvictionPolicy() (evictionPolicy, error) {
+	policy := evictionPolicy{
+		PairTTL:     make(map[string]time.Duration),
+		PairMaxRows: make(map[string]int64),
+	}
+
+	var err error
+	if policy.TTL, err = parseTTL(getEnvWithDefault("CACHE_TTL", "0")); err != nil {
+		return policy, fmt.Errorf("invalid CACHE_TTL: %w", err)
+	}
+	if policy.MaxRows, err = parseMaxRows(getEnvWithDefault("CACHE_MAX_ROWS", "0")); err != nil {
+		return policy, fmt.Errorf("invalid CACHE_MAX_ROWS: %w", err)
+	}
+	err = parsePairSettings("CACHE_TTL_BY_PAIR", func(pair, value string) error {
+		ttl, err := parseTTL(value)
+		policy.PairTTL[pair] = ttl
+		return err
+	})
+	if err != nil {
+		return policy, err
+	}
+	err = parsePairSettings("CACHE_MAX_ROWS_BY_PAIR", func(pair, value string) error {
+		maxRows, err := parseMaxRows(value)
+		policy.PairMaxRows[pair] = maxRows
+		return err
+	})
+	if err != nil {
+		return policy, err
+	}
+
+	policy.Order = strings.ToLower(getEnvWithDefault("CACHE_EVICTION_POLICY", evictLRU))
+	if policy.Order != evictLRU && policy.Order != evictLFU {
+		return policy, fmt.Errorf("invalid CACHE_EVICTION_POLICY %q", policy.Order)
+	}
+	if policy.Interval, err = time.ParseDuration(getEnvWithDefault("CACHE_EVICTION_INTERVAL", "1m")); err != nil || policy.Interval <= 0 {
+		return policy, fmt.Errorf("invalid CACHE_EVICTION_INTERVAL %q", getEnvWithDefault("CACHE_EVICTION_INTERVAL", "1m"))
+	}
+	if policy.BatchSize, err = strconv.Atoi(getEnvWithDefault("CACHE_EVICTION_BATCH_SIZE", "1000")); err != nil || policy.BatchSize <= 0 {
+		return policy, fmt.Errorf("invalid CACHE_EVICTION_BATCH_SIZE %q", getEnvWithDefault("CACHE_EVICTION_BATCH_SIZE", "1000"))
+	}
+	return policy, nil
+}
+
+// parseTTL parses a non-negative duration, 0 meaning no expiry
+func parseTTL(value string) (time.Duration, error) {
+	if value == "0" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err == nil && ttl < 0 {
+		err = fmt.Errorf("negative TTL %v", ttl)
+	}
+	return ttl, err
+}
+
+// parseMaxRows parses a non-negative row limit, 0 meaning unlimited
+func parseMaxRows(value string) (int64, error) {
+	maxRows, err := strconv.ParseInt(value, 10, 64)
+	if err == nil && maxRows < 0 {
+		err = fmt.Errorf("negative row limit %d", maxRows)
+	}
+	return maxRows, err
+}
+
+// limits returns the TTL and row limit of a language pair
+func (p evictionPolicy) limits(sourceLang, targetLang string) (time.Duration, int64) {
+	key := pairKey(sourceLang, targetLang)
+	ttl, ok := p.PairTTL[key]
+	if !ok {
+		ttl = p.TTL
+	}
+	maxRows, ok := p.PairMaxRows[key]
+	if !ok {
+		maxRows = p.MaxRows
+	}
+	return ttl, maxRows
+}
+
+// enabled reports whether any limit is set
+func (p evictionPolicy) enabled() bool {
+	if p.TTL > 0 || p.MaxRows > 0 {
+		return true
+	}
+	for _, ttl := range p.PairTTL {
+		if ttl > 0 {
+			return true
+		}
+	}
+	for _, maxRows := range p.PairMaxRows {
+		if maxRows > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// runEviction prunes the cache every policy.Interval until ctx is done
+func runEviction(ctx context.Context, policy evictionPolicy) {
+	ticker := time.NewTicker(policy.Interval)
+	defer ticker.Stop()
+	for {
+		if err := evictCache(ctx, policy); err != nil {
+			log.Printf("Error evicting cache entries: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// evictCache deletes, per language pair, the entries older than its TTL and
+// then the least used entries over its row limit, policy.BatchSize at a time
+func evictCache(ctx context.Context, policy evictionPolicy) error {
+	counts, err := store.PairCounts(ctx)
+	if err != nil {
+		return fmt.Errorf("error counting cache entries: %w", err)
+	}
+
+	for _, count := range counts {
+		ttl, maxRows := policy.limits(count.SourceLanguage, count.TargetLanguage)
+		rows := count.Rows
+
+		if ttl > 0 {
+			before := time.Now().Add(-ttl)
+			for {
+				deleted, err := store.DeleteExpired(ctx, count.SourceLanguage, count.TargetLanguage, before, policy.BatchSize)
+				if err != nil {
+					return fmt.Errorf("error deleting expired entries: %w", err)
+				}
+				rows -= deleted
+				cacheEvictionMetrics.Add("expired", deleted)
+				if deleted < int64(policy.BatchSize) {
+					break
+				}
+			}
+		}
+
+		for maxRows > 0 && rows > maxRows {
+			deleted, err := store.DeleteLeastUsed(ctx, count.SourceLanguage, count.TargetLanguage, policy.Order, int(min(rows-maxRows, int64(policy.BatchSize))))
+			if err != nil {
+				return fmt.Errorf("error evicting entries: %w", err)
+			}
+			rows -= deleted
+			cacheEvictionMetrics.Add("evicted", deleted)
+			if deleted == 0 {
+				break
+			}
+		}
+	}
+	return nil
+}
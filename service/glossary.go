@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+)
+
+// findGlossary returns the glossary applied to a tenant's translations of a
+// language pair, with its terms longest first, or nil if there is none
+func findGlossary(ctx context.Context, tenant, sourceLang, targetLang string) (*glossary, error) {
+	g, found, err := store.FindGlossary(ctx, tenant, sourceLang, targetLang)
+	if err != nil {
+		return nil, fmt.Errorf("error loading glossary: %w", err)
+	}
+	if !found || len(g.Terms) == 0 {
+		return nil, nil
+	}
+	slices.SortStableFunc(g.Terms, func(a, b glossaryTerm) int {
+		return len(b.SourceTerm) - len(a.SourceTerm)
+	})
+	return &g, nil
+}
+
+// mask replaces the glossary's source terms in a sentence already masked
+// with spans by further mask tokens, preferring the longest term at each
+// position, and returns the masked sentence with spans extended by the target
+// terms the new tokens are replaced by and sources by the text they replaced
+func (g *glossary) mask(sentence string, spans, sources []string) (string, []string, []string) {
+	var b strings.Builder
+	masked := false
+	for i := 0; i < len(sentence); {
+		if strings.HasPrefix(sentence[i:], "⟦") {
+			if loc := maskTokenPattern.FindStringIndex(sentence[i:]); loc != nil && loc[0] == 0 {
+				b.WriteString(sentence[i : i+loc[1]])
+				i += loc[1]
+				continue
+			}
+		}
+		if term, n := g.matchAt(sentence, i); n > 0 {
+			b.WriteString(maskToken(len(spans)))
+			spans = append(spans, term.TargetTerm)
+			sources = append(sources, sentence[i:i+n])
+			masked = true
+			i += n
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(sentence[i:])
+		b.WriteString(sentence[i : i+size])
+		i += size
+	}
+	if !masked {
+		return sentence, spans, sources
+	}
+	return b.String(), spans, sources
+}
+
+// matchAt returns the term found at byte offset i of sentence and its length,
+// requiring word boundaries around terms that start or end with a letter or
+// digit so that "Work" does not match inside "Workspace"
+func (g *glossary) matchAt(sentence string, i int) (glossaryTerm, int) {
+	for _, term := range g.Terms {
+		end := i + len(term.SourceTerm)
+		if end > len(sentence) {
+			continue
+		}
+		candidate := sentence[i:end]
+		if candidate != term.SourceTerm && !(term.IgnoreCase && strings.EqualFold(candidate, term.SourceTerm)) {
+			continue
+		}
+		first, _ := utf8.DecodeRuneInString(term.SourceTerm)
+		last, _ := utf8.DecodeLastRuneInString(term.SourceTerm)
+		before, _ := utf8.DecodeLastRuneInString(sentence[:i])
+		after, _ := utf8.DecodeRuneInString(sentence[end:])
+		if (isWordRune(first) && i > 0 && isWordRune(before)) || (isWordRune(last) && end < len(sentence) && isWordRune(after)) {
+			continue
+		}
+		return term, len(candidate)
+	}
+	return glossaryTerm{}, 0
+}
+
+// isWordRune reports whether r continues a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// glossaryRequest is the body of the glossary endpoints; the tenant and
+// language pair cannot be changed once created
+type glossaryRequest struct {
+	Tenant         string         `json:"tenant"`
+	SourceLanguage string         `json:"source_language"`
+	TargetLanguage string         `json:"target_language"`
+	Terms          []glossaryTerm `json:"terms"`
+}
+
+// validateTerms trims the terms of a request and checks that they are
+// complete and unique
+func (r *glossaryRequest) validateTerms() error {
+	seen := make(map[string]bool, len(r.Terms))
+	for i := range r.Terms {
+		term := &r.Terms[i]
+		term.SourceTerm = strings.TrimSpace(term.SourceTerm)
+		term.TargetTerm = strings.TrimSpace(term.TargetTerm)
+		if term.SourceTerm == "" || term.TargetTerm == "" {
+			return fmt.Errorf("term %d: source_term and target_term are required", i)
+		}
+		if seen[term.SourceTerm] {
+			return fmt.Errorf("term %d: duplicate source_term %q", i, term.SourceTerm)
+		}
+		seen[term.SourceTerm] = true
+	}
+	return nil
+}
+
+// handleGlossaries handles the /admin/glossaries endpoint: GET lists the
+// glossaries without their terms and POST creates one
+func handleGlossaries(w http.ResponseWriter, r *http.Request) {
+	defer recoverFromPanic(w, r)
+
+	switch r.Method {
+	case http.MethodGet:
+		glossaries, err := store.ListGlossaries(r.Context())
+		if err != nil {
+			writeError(w, r, classifyError(err))
+			return
+		}
+		if glossaries == nil {
+			glossaries = []glossary{}
+		}
+		writeJSONResponse(w, http.StatusOK, map[string][]glossary{"glossaries": glossaries})
+
+	case http.MethodPost:
+		var request glossaryRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, r, invalidRequest("invalid request payload"))
+			return
+		}
+		if request.SourceLanguage == "" || request.TargetLanguage == "" || isAutoLanguage(request.SourceLanguage) {
+			writeError(w, r, invalidRequest("source_language and target_language are required"))
+			return
+		}
+		if err := request.validateTerms(); err != nil {
+			writeError(w, r, invalidRequest(err.Error()))
+			return
+		}
+
+		id, err := store.CreateGlossary(r.Context(), glossary{
+			Tenant:         request.Tenant,
+			SourceLanguage: request.SourceLanguage,
+			TargetLanguage: request.TargetLanguage,
+			Terms:          request.Terms,
+		})
+		if errors.Is(err, errGlossaryExists) {
+			writeError(w, r, apiError{Code: codeConflict, Message: "the tenant already has a glossary for this language pair", status: http.StatusConflict, grpcCode: codes.AlreadyExists})
+			return
+		}
+		if err != nil {
+			writeError(w, r, classifyError(err))
+			return
+		}
+		writeGlossary(w, r, id, http.StatusCreated, nil)
+
+	default:
+		writeError(w, r, methodNotAllowed())
+	}
+}
+
+// handleGlossary handles the /admin/glossaries/{id} endpoint: GET shows a
+// glossary with its terms, PUT replaces its terms and DELETE deletes it along
+// with the machine entries cached with it. PUT and DELETE report the
+// corrections pinned under the glossary's previous terms, which no longer
+// match any sentence and must be resubmitted.
+func handleGlossary(w http.ResponseWriter, r *http.Request) {
+	defer recoverFromPanic(w, r)
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, r, notFound("glossary not found"))
+		return
+	}
+
+	var pinned []int64
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var request glossaryRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, r, invalidRequest("invalid request payload"))
+			return
+		}
+		if err := request.validateTerms(); err != nil {
+			writeError(w, r, invalidRequest(err.Error()))
+			return
+		}
+		var found bool
+		pinned, found, err = store.ReplaceGlossaryTerms(r.Context(), id, request.Terms)
+		if err != nil {
+			writeError(w, r, classifyError(err))
+			return
+		}
+		if !found {
+			writeError(w, r, notFound("glossary not found"))
+			return
+		}
+		logPinnedCorrections(id, pinned)
+	case http.MethodDelete:
+		pinned, found, err := store.DeleteGlossary(r.Context(), id)
+		if err != nil {
+			writeError(w, r, classifyError(err))
+			return
+		}
+		if !found {
+			writeError(w, r, notFound("glossary not found"))
+			return
+		}
+		logPinnedCorrections(id, pinned)
+		if pinned == nil {
+			pinned = []int64{}
+		}
+		writeJSONResponse(w, http.StatusOK, map[string][]int64{"pinned_corrections": pinned})
+		return
+	default:
+		writeError(w, r, methodNotAllowed())
+		return
+	}
+
+	writeGlossary(w, r, id, http.StatusOK, pinned)
+}
+
+// logPinnedCorrections logs the corrections left unmatched by a glossary
+// change
+func logPinnedCorrections(id int64, pinned []int64) {
+	if len(pinned) > 0 {
+		log.Printf("Glossary %d changed, %d corrections cached with its previous terms must be resubmitted: %v", id, len(pinned), pinned)
+	}
+}
+
+// writeGlossary writes a glossary with its terms, along with the pinned
+// corrections a change left unmatched, if any
+func writeGlossary(w http.ResponseWriter, r *http.Request, id int64, status int, pinned []int64) {
+	g, found, err := store.GetGlossary(r.Context(), id)
+	if err != nil {
+		writeError(w, r, classifyError(err))
+		return
+	}
+	if !found {
+		writeError(w, r, notFound("glossary not found"))
+		return
+	}
+	if g.Terms == nil {
+		g.Terms = []glossaryTerm{}
+	}
+	writeJSONResponse(w, status, struct {
+		glossary
+		PinnedCorrections []int64 `json:"pinned_corrections,omitempty"`
+	}{g, pinned})
+}
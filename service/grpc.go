@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	translatepb "service/translationsapi/service"
+
+	"google.golang.org/grpc"
+)
+
+// translatorServer exposes the cached translation pipeline over the same
+// translate.Translator contract served by the Python translation service
+type translatorServer struct {
+	translatepb.UnimplementedTranslatorServer
+}
+
+// Translate handles the translate.Translator/Translate RPC
+func (s *translatorServer) Translate(ctx context.Context, req *translatepb.TranslationRequest) (*translatepb.TranslationResponse, error) {
+	if req.GetText() == "" || req.GetTargetLanguage() == "" {
+		return nil, invalidRequest("text and target_language are required").grpcError(ctx)
+	}
+
+	if req.GetMaxDistance() < 0 || req.GetMaxDistance() > 2 {
+		return nil, invalidRequest("max_distance must be between 0 and 2").grpcError(ctx)
+	}
+
+	result, err := processTranslation(ctx, req.GetText(), req.GetSourceLanguage(), req.GetTargetLanguage(), translationOptions{
+		MaxDistance: req.GetMaxDistance(),
+		ExactOnly:   req.GetExactOnly(),
+		Debug:       req.GetDebug(),
+		Verbose:     req.GetVerbose(),
+		Tenant:      req.GetTenant(),
+	})
+	if err != nil {
+		return nil, classifyError(err).grpcError(ctx)
+	}
+
+	res := &translatepb.TranslationResponse{
+		Translation:         result.Translation,
+		Distance:            result.Distance,
+		DetectedLanguage:    result.DetectedLanguage,
+		DetectionConfidence: result.DetectionConfidence,
+		Route:               result.Route,
+		GlossaryId:          result.GlossaryID,
+		Flagged:             flaggedSegmentsProto(result.Flagged),
+	}
+	for _, candidate := range result.Candidates {
+		res.Candidates = append(res.Candidates, &translatepb.CacheCandidate{
+			Sentence:   int32(candidate.Sentence),
+			Id:         candidate.ID,
+			SourceText: candidate.SourceText,
+			TargetText: candidate.TargetText,
+			Distance:   candidate.Distance,
+			Score:      candidate.Score,
+			Outcome:    candidate.Outcome,
+			Selected:   candidate.Selected,
+			Source:     candidate.Origin,
+			Priority:   int32(candidate.Priority),
+		})
+	}
+	for _, segment := range result.Segments {
+		res.Segments = append(res.Segments, &translatepb.SegmentDetail{
+			Index:          int32(segment.Index),
+			SourceLanguage: segment.SourceLanguage,
+			TargetLanguage: segment.TargetLanguage,
+			Source:         segment.Source,
+			Translation:    segment.Translation,
+			Cache:          segment.Cache,
+			Distance:       segment.Distance,
+			CacheId:        segment.CacheID,
+			LatencyMs:      segment.LatencyMs,
+			Backend:        segment.Backend,
+		})
+	}
+	return res, nil
+}
+
+// BatchTranslate handles the translate.Translator/BatchTranslate RPC
+func (s *translatorServer) BatchTranslate(ctx context.Context, req *translatepb.BatchTranslationRequest) (*translatepb.BatchTranslationResponse, error) {
+	if len(req.GetItems()) == 0 {
+		return nil, invalidRequest("items are required").grpcError(ctx)
+	}
+	if len(req.GetItems()) > maxBatchSize() {
+		return nil, batchTooLarge(maxBatchSize()).grpcError(ctx)
+	}
+
+	items := make([]batchItem, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = batchItem{
+			ID:             item.GetId(),
+			Text:           item.GetText(),
+			SourceLanguage: item.GetSourceLanguage(),
+			TargetLanguage: item.GetTargetLanguage(),
+			Tenant:         item.GetTenant(),
+		}
+	}
+
+	res := &translatepb.BatchTranslationResponse{}
+	for _, result := range processBatch(ctx, items) {
+		res.Results = append(res.Results, &translatepb.BatchTranslationResult{
+			Id:          result.ID,
+			Translation: result.Translation,
+			Error:       result.Error,
+			ErrorCode:   result.ErrorCode,
+			Retryable:   result.Retryable,
+			Flagged:     flaggedSegmentsProto(result.Flagged),
+
+			DetectedLanguage:    result.DetectedLanguage,
+			DetectionConfidence: result.DetectionConfidence,
+		})
+	}
+	return res, nil
+}
+
+// TranslateBatch handles the translate.Translator/TranslateBatch RPC,
+// translating the texts TRANSLATION_CONCURRENCY at a time and reporting the
+// texts that failed without failing the call
+func (s *translatorServer) TranslateBatch(ctx context.Context, req *translatepb.TranslationBatchRequest) (*translatepb.TranslationBatchResponse, error) {
+	if len(req.GetTexts()) == 0 || req.GetTargetLanguage() == "" {
+		return nil, invalidRequest("texts and target_language are required").grpcError(ctx)
+	}
+	if len(req.GetTexts()) > maxBatchSize() {
+		return nil, batchTooLarge(maxBatchSize()).grpcError(ctx)
+	}
+
+	// Each text goes through the cache on its own; concurrent embedding calls
+	// are still merged by the embedding batcher
+	texts := req.GetTexts()
+	translations := make([]string, len(texts))
+	detected := make([]string, len(texts))
+	confidences := make([]float64, len(texts))
+	failures := make([]*apiError, len(texts))
+	err := forEachConcurrently(ctx, len(texts), translationConcurrency(), func(ctx context.Context, i int) error {
+		if texts[i] == "" {
+			return nil
+		}
+		result, err := processTranslation(ctx, texts[i], req.GetSourceLanguage(), req.GetTargetLanguage(), translationOptions{})
+		if err != nil {
+			e := classifyError(err)
+			if e.cause != nil {
+				log.Printf("Error translating segment %d: %v", i, err)
+			}
+			failures[i] = &e
+			return nil
+		}
+		translations[i] = result.Translation
+		detected[i], confidences[i] = result.DetectedLanguage, result.DetectionConfidence
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError(err).grpcError(ctx)
+	}
+
+	res := &translatepb.TranslationBatchResponse{Translations: translations}
+	if isAutoLanguage(req.GetSourceLanguage()) {
+		res.DetectedLanguages, res.DetectionConfidences = detected, confidences
+	}
+	for i, e := range failures {
+		if e != nil {
+			res.Errors = append(res.Errors, &translatepb.SegmentError{
+				Index:     int32(i),
+				Error:     e.Message,
+				ErrorCode: e.Code,
+				Retryable: e.Retryable,
+			})
+		}
+	}
+	return res, nil
+}
+
+// TranslateStream handles the translate.Translator/TranslateStream RPC,
+// sending each translated sentence in completion order
+func (s *translatorServer) TranslateStream(req *translatepb.TranslationRequest, stream grpc.ServerStreamingServer[translatepb.TranslationSegment]) error {
+	if req.GetText() == "" || req.GetTargetLanguage() == "" {
+		return invalidRequest("text and target_language are required").grpcError(stream.Context())
+	}
+	if req.GetMaxDistance() < 0 || req.GetMaxDistance() > 2 {
+		return invalidRequest("max_distance must be between 0 and 2").grpcError(stream.Context())
+	}
+
+	opts := translationOptions{
+		MaxDistance: req.GetMaxDistance(),
+		ExactOnly:   req.GetExactOnly(),
+		Tenant:      req.GetTenant(),
+		OnSegment: func(event segmentEvent) {
+			// A failed send means the client is gone, which also cancels the stream context
+			if err := stream.Send(&translatepb.TranslationSegment{
+				Index:       int32(event.Index),
+				Source:      event.Source,
+				Translation: event.Translation,
+				Leading:     event.Leading,
+				Trailing:    event.Trailing,
+				Cached:      event.Cached,
+				ElapsedMs:   event.ElapsedMs,
+				Missing:     event.Missing,
+				Duplicated:  event.Duplicated,
+			}); err != nil {
+				log.Printf("Error sending segment: %v", err)
+			}
+		},
+	}
+
+	_, err := processTranslation(stream.Context(), req.GetText(), req.GetSourceLanguage(), req.GetTargetLanguage(), opts)
+	if err != nil {
+		return classifyError(err).grpcError(stream.Context())
+	}
+	return nil
+}
+
+// ListLanguages handles the translate.Translator/ListLanguages RPC, serving
+// the cached list of pairs supported by the translation service
+func (s *translatorServer) ListLanguages(ctx context.Context, req *translatepb.ListLanguagesRequest) (*translatepb.ListLanguagesResponse, error) {
+	pairs, err := languages.Pairs(ctx)
+	if err != nil {
+		return nil, classifyError(err).grpcError(ctx)
+	}
+
+	res := &translatepb.ListLanguagesResponse{}
+	for _, pair := range pairs {
+		res.Pairs = append(res.Pairs, &translatepb.LanguagePair{
+			SourceLanguage: pair.SourceLanguage,
+			TargetLanguage: pair.TargetLanguage,
+		})
+	}
+	return res, nil
+}
+
+// flaggedSegmentsProto converts flagged sentences to their messages
+func flaggedSegmentsProto(flagged []flaggedSegment) []*translatepb.FlaggedSegment {
+	var messages []*translatepb.FlaggedSegment
+	for _, segment := range flagged {
+		messages = append(messages, &translatepb.FlaggedSegment{
+			Index:      int32(segment.Index),
+			Missing:    segment.Missing,
+			Duplicated: segment.Duplicated,
+		})
+	}
+	return messages
+}
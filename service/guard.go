@@ -0,0 +1,194 @@
+package main
+
+import (
+	"expvar"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"service/segmenter"
+)
+
+// cacheGuardMetrics counts semantic cache hit verification outcomes by reason,
+// exposed on /debug/vars
+var cacheGuardMetrics = expvar.NewMap("cache_guard")
+
+// Outcomes of verifying a semantic cache hit
+const (
+	guardAccepted            = "accepted"
+	guardPatched             = "patched_numbers"
+	guardRejectedURL         = "rejected_url"
+	guardRejectedEmail       = "rejected_email"
+	guardRejectedPlaceholder = "rejected_placeholder"
+	guardRejectedNumber      = "rejected_number"
+	guardRejectedNegation    = "rejected_negation"
+)
+
+var (
+	urlPattern         = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+[^\s<>".,;:!?)\]]`)
+	emailPattern       = regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`)
+	placeholderPattern = regexp.MustCompile(`\{\{?[^{}\s]+\}?\}|%(?:\d+\$)?[-+#0]*\d*(?:\.\d+)?[sdifvqxXeEgGtTcbop]|\$(?:\{\w+\}|[A-Za-z_]\w*)`)
+	numberPattern      = regexp.MustCompile(`\d+(?:[.,:/-]\d+)*`)
+)
+
+// negationWords lists, per language, the words that invert a sentence's meaning
+var negationWords = map[string][]string{
+	"en": {"not", "no", "never", "none", "nobody", "nothing", "neither", "nor", "nowhere", "cannot"},
+	"es": {"no", "nunca", "jamás", "nada", "nadie", "ninguno", "ninguna", "ningún", "tampoco", "ni"},
+	"fr": {"ne", "n", "pas", "jamais", "rien", "personne", "aucun", "aucune", "ni", "nullement"},
+	"de": {"nicht", "kein", "keine", "keinen", "keinem", "keiner", "keines", "nie", "niemals", "nichts", "niemand", "weder"},
+	"zh": {"不", "没", "沒", "别", "別", "无", "無", "非", "未"},
+}
+
+// protectedTokens holds the tokens of a sentence that a reused translation
+// must agree on
+type protectedTokens struct {
+	masked       []string
+	urls         []string
+	emails       []string
+	placeholders []string
+	numbers      []string
+	negations    int
+}
+
+// extractProtectedTokens finds the protected tokens of a sentence; mask
+// tokens, URLs, emails and placeholders are removed before numbers are
+// collected so that their digits are not counted twice
+func extractProtectedTokens(lang, sentence string) protectedTokens {
+	var tokens protectedTokens
+	rest := sentence
+	for _, p := range []struct {
+		pattern *regexp.Regexp
+		dest    *[]string
+	}{
+		{maskTokenPattern, &tokens.masked},
+		{urlPattern, &tokens.urls},
+		{emailPattern, &tokens.emails},
+		{placeholderPattern, &tokens.placeholders},
+	} {
+		*p.dest = p.pattern.FindAllString(rest, -1)
+		rest = p.pattern.ReplaceAllString(rest, " ")
+	}
+	tokens.numbers = numberPattern.FindAllString(rest, -1)
+	tokens.negations = countNegations(lang, rest)
+	return tokens
+}
+
+// countNegations counts the negation words of a language in text
+func countNegations(lang, text string) int {
+	words := negationWords[segmenter.BaseLanguage(lang)]
+	if len(words) == 0 {
+		return 0
+	}
+
+	lower := strings.ToLower(text)
+	count := 0
+	if segmenter.BaseLanguage(lang) == "zh" {
+		for _, word := range words {
+			count += strings.Count(lower, word)
+		}
+		return count
+	}
+
+	for _, word := range strings.FieldsFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) && r != '\'' }) {
+		if strings.HasSuffix(word, "n't") {
+			count++
+			continue
+		}
+		if i := strings.IndexRune(word, '\''); i >= 0 {
+			// French elision, e.g. "n'est"
+			word = word[:i]
+		}
+		if slices.Contains(words, word) {
+			count++
+		}
+	}
+	return count
+}
+
+// verifyCacheHit checks a semantic cache hit against the incoming sentence.
+// It returns the translation to use, patched if only numbers differ and can
+// be substituted unambiguously, and the outcome recorded in the metrics.
+func verifyCacheHit(sourceLang, sentence, cachedSource, cachedTarget string) (string, string) {
+	translation, outcome := checkProtectedTokens(sourceLang, sentence, cachedSource, cachedTarget)
+	cacheGuardMetrics.Add(outcome, 1)
+	return translation, outcome
+}
+
+// guardPassed reports whether a verification outcome allows reusing the hit
+func guardPassed(outcome string) bool {
+	return outcome == guardAccepted || outcome == guardPatched
+}
+
+// checkProtectedTokens compares the protected tokens of the sentence and the
+// cached source, returning the usable translation and the outcome
+func checkProtectedTokens(sourceLang, sentence, cachedSource, cachedTarget string) (string, string) {
+	want := extractProtectedTokens(sourceLang, sentence)
+	got := extractProtectedTokens(sourceLang, cachedSource)
+
+	switch {
+	case !sameTokens(want.urls, got.urls):
+		return "", guardRejectedURL
+	case !sameTokens(want.emails, got.emails):
+		return "", guardRejectedEmail
+	case !sameTokens(want.placeholders, got.placeholders), !sameTokens(want.masked, got.masked):
+		return "", guardRejectedPlaceholder
+	case want.negations != got.negations:
+		return "", guardRejectedNegation
+	case slices.Equal(want.numbers, got.numbers):
+		return cachedTarget, guardAccepted
+	}
+
+	// Substitute differing numbers positionally when each old number occurs
+	// exactly once in the cached translation
+	if len(want.numbers) != len(got.numbers) {
+		return "", guardRejectedNumber
+	}
+	patched := cachedTarget
+	for i := range want.numbers {
+		if want.numbers[i] == got.numbers[i] {
+			continue
+		}
+		locations := numberLocations(patched)
+		match := -1
+		for j, loc := range locations {
+			if patched[loc[0]:loc[1]] != got.numbers[i] {
+				continue
+			}
+			if match >= 0 || slices.Contains(want.numbers, got.numbers[i]) {
+				return "", guardRejectedNumber
+			}
+			match = j
+		}
+		if match < 0 {
+			return "", guardRejectedNumber
+		}
+		loc := locations[match]
+		patched = patched[:loc[0]] + want.numbers[i] + patched[loc[1]:]
+	}
+	return patched, guardPatched
+}
+
+// numberLocations finds the numbers of a translation outside mask tokens,
+// URLs, emails and placeholders, whose digits must never be patched
+func numberLocations(text string) [][]int {
+	blanked := text
+	for _, pattern := range []*regexp.Regexp{maskTokenPattern, urlPattern, emailPattern, placeholderPattern} {
+		blanked = pattern.ReplaceAllStringFunc(blanked, func(match string) string {
+			return strings.Repeat(" ", len(match))
+		})
+	}
+	return numberPattern.FindAllStringIndex(blanked, -1)
+}
+
+// sameTokens reports whether two token lists hold the same tokens in any order
+func sameTokens(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
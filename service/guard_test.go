@@ -0,0 +1,72 @@
+package main
+
+import "testing"
+
+func TestCheckProtectedTokens(t *testing.T) {
+	tests := []struct {
+		name         string
+		lang         string
+		sentence     string
+		cachedSource string
+		cachedTarget string
+		want         string
+		wantOutcome  string
+	}{
+		{"same tokens", "en", "Visit https://example.com for 3 offers.", "Visit https://example.com for 3 deals.", "Visita https://example.com para 3 ofertas.", "Visita https://example.com para 3 ofertas.", guardAccepted},
+		{"different url", "en", "Visit https://example.com today.", "Visit https://example.org today.", "Visita https://example.org hoy.", "", guardRejectedURL},
+		{"missing url", "en", "Visit https://example.com today.", "Visit our site today.", "Visita nuestro sitio hoy.", "", guardRejectedURL},
+		{"different email", "en", "Write to ana@example.com now.", "Write to bob@example.com now.", "Escribe a bob@example.com ahora.", "", guardRejectedEmail},
+		{"different placeholder", "en", "Hello {name}, welcome.", "Hello {user}, welcome.", "Hola {user}, bienvenido.", "", guardRejectedPlaceholder},
+		{"different printf verb", "en", "Found %d files.", "Found %s files.", "Encontrados %s archivos.", "", guardRejectedPlaceholder},
+		{"different mask tokens", "en", "Open ⟦0⟧ and ⟦1⟧.", "Open ⟦0⟧ now.", "Abre ⟦0⟧ ahora.", "", guardRejectedPlaceholder},
+		{"added negation", "en", "Do not delete the file.", "Do delete the file.", "Borra el archivo.", "", guardRejectedNegation},
+		{"contracted negation", "en", "You can't delete the file.", "You can delete the file.", "Puedes borrar el archivo.", "", guardRejectedNegation},
+		{"french elision", "fr", "Il n'est pas là.", "Il est là.", "He is there.", "", guardRejectedNegation},
+		{"chinese negation", "zh", "我不喜欢。", "我喜欢。", "I like it.", "", guardRejectedNegation},
+		{"language without negation words", "xx", "Do not delete the file.", "Do delete the file.", "Borra el archivo.", "Borra el archivo.", guardAccepted},
+		{"patched number", "en", "Your order ships in 5 days.", "Your order ships in 3 days.", "Tu pedido se envía en 3 días.", "Tu pedido se envía en 5 días.", guardPatched},
+		{"patched numbers in order", "en", "Pick 4 of 9 items.", "Pick 2 of 7 items.", "Elige 2 de 7 artículos.", "Elige 4 de 9 artículos.", guardPatched},
+		{"patched reordered numbers", "en", "From 4 to 9 items.", "From 2 to 7 items.", "De 7 a 2 artículos, al revés.", "De 9 a 4 artículos, al revés.", guardPatched},
+		{"patched decimal", "en", "It costs 3.50 dollars.", "It costs 2.75 dollars.", "Cuesta 2.75 dólares.", "Cuesta 3.50 dólares.", guardPatched},
+		{"localized decimal", "en", "It costs 3.50 dollars.", "It costs 2.75 dollars.", "Cuesta 2,75 dólares.", "", guardRejectedNumber},
+		{"number count differs", "en", "Pick 4 of 9 items.", "Pick 2 items.", "Elige 2 artículos.", "", guardRejectedNumber},
+		{"number missing from translation", "en", "Ships in 5 days.", "Ships in 3 days.", "Se envía en tres días.", "", guardRejectedNumber},
+		{"number repeated in translation", "en", "Ships in 5 days.", "Ships in 3 days.", "Se envía en 3 días (3 días hábiles).", "", guardRejectedNumber},
+		{"swapped numbers", "en", "Pick 7 of 2 items.", "Pick 2 of 7 items.", "Elige 2 de 7 artículos.", "", guardRejectedNumber},
+		{"numbers inside mask tokens are not patched", "en", "Delete ⟦0⟧ and 1 item.", "Delete ⟦0⟧ and 0 item.", "Borra ⟦0⟧ y 0 artículos.", "Borra ⟦0⟧ y 1 artículos.", guardPatched},
+		{"number only inside a mask token", "en", "Delete ⟦0⟧ and 1 item.", "Delete ⟦0⟧ and 0 item.", "Borra ⟦0⟧ y un artículo.", "", guardRejectedNumber},
+		{"numbers inside placeholders are not patched", "en", "Show %1$s of 2 items.", "Show %1$s of 1 items.", "Muestra %1$s de 1 artículos.", "Muestra %1$s de 2 artículos.", guardPatched},
+		{"numbers inside urls are not patched", "en", "See https://example.com/page/2 for 5 tips.", "See https://example.com/page/2 for 3 tips.", "Mira https://example.com/page/2 para 3 consejos.", "Mira https://example.com/page/2 para 5 consejos.", guardPatched},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, outcome := checkProtectedTokens(test.lang, test.sentence, test.cachedSource, test.cachedTarget)
+			if got != test.want || outcome != test.wantOutcome {
+				t.Errorf("checkProtectedTokens(%q, %q) = %q, %s; want %q, %s", test.sentence, test.cachedSource, got, outcome, test.want, test.wantOutcome)
+			}
+		})
+	}
+}
+
+func TestCountNegations(t *testing.T) {
+	tests := []struct {
+		lang string
+		text string
+		want int
+	}{
+		{"en", "I do not know nothing.", 2},
+		{"en", "Don't stop, won't stop.", 2},
+		{"en-US", "Nobody came.", 1},
+		{"en", "Notice the note.", 0},
+		{"es", "No quiero nada.", 2},
+		{"fr", "Je n'ai jamais dit ça.", 2},
+		{"de", "Das ist keine gute Idee.", 1},
+		{"zh", "我没有不喜欢。", 2},
+		{"ja", "いいえ。", 0},
+	}
+	for _, test := range tests {
+		if got := countNegations(test.lang, test.text); got != test.want {
+			t.Errorf("countNegations(%q, %q) = %d, want %d", test.lang, test.text, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// hashingDimensions matches the similarity index created by the cache schema
+const hashingDimensions = 384
+
+// hashingEmbedder is an in-process Embedder using feature hashing of words
+// and character trigrams. It needs no model or external service, at the cost
+// of matching surface similarity rather than meaning.
+type hashingEmbedder struct {
+	dimensions int
+}
+
+// newHashingEmbedder creates a hashing embedder sized for the cache schema
+func newHashingEmbedder() *hashingEmbedder {
+	return &hashingEmbedder{dimensions: hashingDimensions}
+}
+
+func (e *hashingEmbedder) Name() string {
+	return "hashing"
+}
+
+func (e *hashingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, string, error) {
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embeddings[i] = e.embed(text)
+	}
+	return embeddings, fmt.Sprintf("hashing-%d", e.dimensions), nil
+}
+
+// embed hashes the words and the padded character trigrams of text into a
+// unit vector
+func (e *hashingEmbedder) embed(text string) []float32 {
+	vector := make([]float32, e.dimensions)
+	normalized := strings.ToLower(strings.Join(strings.Fields(norm.NFC.String(text)), " "))
+
+	words := strings.FieldsFunc(normalized, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, word := range words {
+		e.add(vector, "w:"+word, 1)
+	}
+
+	runes := []rune(" " + normalized + " ")
+	for i := 0; i+3 <= len(runes); i++ {
+		e.add(vector, "t:"+string(runes[i:i+3]), 0.5)
+	}
+
+	var sumSquares float64
+	for _, v := range vector {
+		sumSquares += float64(v) * float64(v)
+	}
+	if sumSquares > 0 {
+		scale := float32(1 / math.Sqrt(sumSquares))
+		for i := range vector {
+			vector[i] *= scale
+		}
+	}
+	return vector
+}
+
+// add adds weight to the dimension feature hashes to, with a hashed sign so
+// that collisions tend to cancel out
+func (e *hashingEmbedder) add(vector []float32, feature string, weight float32) {
+	h := fnv.New32a()
+	h.Write([]byte(feature))
+	sum := h.Sum32()
+	if sum&(1<<31) != 0 {
+		weight = -weight
+	}
+	vector[int(sum%uint32(e.dimensions))] += weight
+}
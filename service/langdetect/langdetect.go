@@ -0,0 +1,115 @@
+// Package langdetect identifies the language of short texts in-process, using
+// the Unicode script of the text and, for Latin script, stopword and
+// diacritic frequencies.
+package langdetect
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Result is a detected language with a confidence between 0 and 1
+type Result struct {
+	Language   string
+	Confidence float64
+}
+
+// scripts maps Unicode scripts written by a single supported language to it
+var scripts = []struct {
+	table    *unicode.RangeTable
+	language string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Thai, "th"},
+}
+
+// Detect returns the most likely language of text, or false if the text
+// carries no usable signal
+func Detect(text string) (Result, bool) {
+	counts := make(map[string]int)
+	latin, letters := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+			continue
+		}
+		for _, s := range scripts {
+			if unicode.Is(s.table, r) {
+				counts[s.language]++
+				break
+			}
+		}
+	}
+	if letters == 0 {
+		return Result{}, false
+	}
+
+	// Any kana marks Japanese even though most of its characters are Han
+	if counts["ja"] > 0 {
+		counts["ja"] += counts["zh"]
+		delete(counts, "zh")
+	}
+
+	best, bestCount := "", 0
+	for language, count := range counts {
+		if count > bestCount {
+			best, bestCount = language, count
+		}
+	}
+	if bestCount > latin {
+		return Result{Language: best, Confidence: float64(bestCount) / float64(letters)}, true
+	}
+
+	result, ok := detectLatin(text)
+	if ok {
+		result.Confidence *= float64(latin) / float64(letters)
+	}
+	return result, ok
+}
+
+// detectLatin scores Latin script text against per-language stopwords, common
+// words and characteristic letters
+func detectLatin(text string) (Result, bool) {
+	scores := make(map[string]float64)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, word := range words {
+		for language, list := range stopwords {
+			if list[word] || commonWords[language][word] {
+				scores[language]++
+			}
+		}
+		for _, r := range word {
+			for language, letters := range characteristicLetters {
+				if strings.ContainsRune(letters, r) {
+					scores[language] += 0.5
+				}
+			}
+		}
+	}
+
+	best, bestScore, total := "", 0.0, 0.0
+	for language, score := range scores {
+		total += score
+		if score > bestScore || (score == bestScore && language < best) {
+			best, bestScore = language, score
+		}
+	}
+	if bestScore == 0 {
+		return Result{}, false
+	}
+	return Result{Language: best, Confidence: bestScore / total}, true
+}
@@ -0,0 +1,57 @@
+package langdetect
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		text           string
+		want           string
+		wantConfidence float64 // Lower bound
+	}{
+		{"Hello world", "en", 0.9},
+		{"Save changes", "en", 0.9},
+		{"The order is on its way.", "en", 0.9},
+		{"Guten Morgen", "de", 0.9},
+		{"Das ist nicht gut.", "de", 0.7},
+		{"Hola, ¿cómo estás?", "es", 0.8},
+		{"Guardar cambios", "es", 0.9},
+		{"Bonjour, c'est très bien.", "fr", 0.9},
+		{"Ciao, come stai?", "it", 0.9},
+		{"Obrigado, você é muito gentil.", "pt", 0.7},
+		{"Привет, мир", "ru", 0.9},
+		{"こんにちは世界", "ja", 0.9},
+		{"你好，世界", "zh", 0.9},
+		{"안녕하세요", "ko", 0.9},
+		{"مرحبا بالعالم", "ar", 0.9},
+		{"Γεια σου κόσμε", "el", 0.9},
+	}
+	for _, test := range tests {
+		got, ok := Detect(test.text)
+		if !ok || got.Language != test.want || got.Confidence < test.wantConfidence {
+			t.Errorf("Detect(%q) = %+v, %v; want %s with confidence of at least %v", test.text, got, ok, test.want, test.wantConfidence)
+		}
+	}
+}
+
+func TestDetectMixedScripts(t *testing.T) {
+	got, ok := Detect("Привет мир hello")
+	if !ok || got.Language != "ru" || got.Confidence >= 1 {
+		t.Errorf("Detect() = %+v, %v; want ru with reduced confidence", got, ok)
+	}
+}
+
+func TestDetectAmbiguous(t *testing.T) {
+	// "una" is both Spanish and Italian
+	got, ok := Detect("Una casa")
+	if !ok || got.Confidence > 0.5 {
+		t.Errorf("Detect() = %+v, %v; want a guess with confidence of at most 0.5", got, ok)
+	}
+}
+
+func TestDetectUndetected(t *testing.T) {
+	for _, text := range []string{"", "  ", "12345", "!!! ???", "Xyzzy plugh"} {
+		if got, ok := Detect(text); ok {
+			t.Errorf("Detect(%q) = %+v, want no result", text, got)
+		}
+	}
+}
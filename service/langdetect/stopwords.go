@@ -0,0 +1,61 @@
+package langdetect
+
+// stopwords lists, per Latin script language, frequent function words
+var stopwords = map[string]map[string]bool{
+	"en": set("the", "and", "is", "are", "was", "were", "of", "to", "in", "that", "it", "you", "he", "she",
+		"we", "they", "this", "with", "for", "on", "not", "be", "have", "has", "i", "my", "your", "what",
+		"how", "do", "does", "will", "would", "can", "at", "from", "by", "an", "a", "or", "but", "there"),
+	"es": set("el", "la", "los", "las", "de", "del", "que", "y", "en", "un", "una", "es", "son", "por",
+		"con", "para", "no", "se", "su", "al", "lo", "como", "más", "pero", "yo", "tú", "muy", "está",
+		"estoy", "hola", "qué", "cómo", "mi", "este", "esta", "hay", "tiene", "ser", "fue"),
+	"fr": set("le", "la", "les", "de", "des", "du", "et", "est", "un", "une", "que", "qui", "dans", "pour",
+		"pas", "ne", "sur", "avec", "il", "elle", "nous", "vous", "ils", "je", "ce", "cette", "sont",
+		"au", "aux", "mais", "ou", "très", "bonjour", "c'est", "j'ai", "n'est", "suis", "être", "avoir"),
+	"de": set("der", "die", "das", "und", "ist", "sind", "nicht", "ein", "eine", "einen", "zu", "mit",
+		"den", "dem", "des", "auf", "für", "von", "ich", "du", "er", "sie", "wir", "ihr", "es", "im",
+		"auch", "sich", "wie", "was", "aber", "oder", "haben", "hat", "bin", "wird", "hallo", "danke"),
+	"it": set("il", "lo", "la", "gli", "le", "di", "che", "e", "è", "un", "una", "per", "non", "sono",
+		"con", "del", "della", "ciao", "come", "questo", "questa", "ma", "anche", "molto", "io", "tu"),
+	"pt": set("o", "os", "a", "as", "de", "do", "da", "dos", "das", "que", "e", "é", "um", "uma", "em",
+		"não", "para", "com", "por", "mais", "eu", "você", "ele", "ela", "olá", "obrigado", "muito"),
+}
+
+// commonWords lists, per Latin script language, frequent words of greetings
+// and interface strings, which are often too short to contain a stopword
+var commonWords = map[string]map[string]bool{
+	"en": set("hello", "hi", "world", "good", "morning", "evening", "night", "welcome", "please", "thanks",
+		"thank", "yes", "save", "changes", "delete", "open", "close", "cancel", "file", "files",
+		"settings", "search", "sign", "account", "password", "new", "edit", "help", "back", "next",
+		"show", "more", "home", "message", "today", "day", "days", "order", "item", "items"),
+	"es": set("buenos", "buenas", "días", "tardes", "noches", "mundo", "bienvenido", "gracias", "sí",
+		"guardar", "cambios", "borrar", "eliminar", "cerrar", "archivo", "archivos", "configuración",
+		"buscar", "cuenta", "contraseña", "nuevo", "ayuda", "volver", "siguiente", "mensaje", "hoy"),
+	"fr": set("bonsoir", "monde", "bienvenue", "merci", "oui", "enregistrer", "modifications", "supprimer",
+		"ouvrir", "fermer", "annuler", "fichier", "fichiers", "paramètres", "rechercher", "compte",
+		"nouveau", "aide", "retour", "suivant", "aujourd'hui", "jour", "jours"),
+	"de": set("guten", "morgen", "tag", "abend", "nacht", "willkommen", "bitte", "ja", "nein", "welt",
+		"speichern", "änderungen", "löschen", "öffnen", "schließen", "abbrechen", "datei", "dateien",
+		"einstellungen", "suchen", "anmelden", "konto", "passwort", "neu", "hilfe", "zurück", "heute"),
+	"it": set("buongiorno", "buonasera", "mondo", "benvenuto", "grazie", "sì", "salva", "modifiche",
+		"elimina", "apri", "chiudi", "annulla", "impostazioni", "cerca", "nuovo", "aiuto", "oggi"),
+	"pt": set("bom", "boa", "dia", "tarde", "noite", "obrigada", "sim", "salvar", "alterações", "excluir",
+		"fechar", "arquivo", "configurações", "pesquisar", "conta", "senha", "novo", "ajuda", "hoje"),
+}
+
+// characteristicLetters lists, per Latin script language, letters that are
+// rare in the other supported languages
+var characteristicLetters = map[string]string{
+	"es": "ñ¿¡áíóú",
+	"fr": "çèêëàâîïôûùœ",
+	"de": "äöüß",
+	"it": "ìò",
+	"pt": "ãõç",
+}
+
+func set(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
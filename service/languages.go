@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errUnsupportedPair is returned when no translation backend can translate
+// between the requested languages
+var errUnsupportedPair = errors.New("unsupported language pair")
+
+// languagePair is a supported translation direction
+type languagePair struct {
+	SourceLanguage string `json:"source_language"`
+	TargetLanguage string `json:"target_language"`
+}
+
+// languageLister lists the language pairs that can be translated
+type languageLister interface {
+	Languages(ctx context.Context) ([]languagePair, error)
+}
+
+// Bounds of the delay before retrying a failed refresh of the supported
+// pairs, doubling with each consecutive failure
+const (
+	languageRetryMin = 5 * time.Second
+	languageRetryMax = 5 * time.Minute
+)
+
+// languageCatalog caches the language pairs supported by the translation
+// backends, refreshing them once they are older than ttl
+type languageCatalog struct {
+	lister languageLister
+	ttl    time.Duration
+	pivot  string // Intermediate language for pairs without a direct package
+
+	mu         sync.Mutex
+	pairs      []languagePair
+	supported  map[string]bool
+	fetched    time.Time
+	refreshing chan struct{} // Closed when the running refresh completes
+	err        error         // Error of the last refresh, if it failed
+	failures   int           // Consecutive failed refreshes
+	retryAt    time.Time     // No refresh is attempted before then
+}
+
+// newLanguageCatalog creates a catalog of the pairs listed by lister,
+// routing unsupported pairs through pivot when both legs are supported
+func newLanguageCatalog(lister languageLister, ttl time.Duration, pivot string) *languageCatalog {
+	return &languageCatalog{lister: lister, ttl: ttl, pivot: pivot}
+}
+
+// languageCatalogTTL returns how long the supported pairs are cached, read
+// from LANGUAGES_CACHE_TTL
+func languageCatalogTTL() time.Duration {
+	ttl, err := time.ParseDuration(getEnvWithDefault("LANGUAGES_CACHE_TTL", "5m"))
+	if err != nil || ttl < 0 {
+		return 5 * time.Minute
+	}
+	return ttl
+}
+
+// pivotLanguage returns the intermediate language read from PIVOT_LANGUAGE;
+// "none" disables pivot translation
+func pivotLanguage() string {
+	pivot := strings.TrimSpace(getEnvWithDefault("PIVOT_LANGUAGE", "en"))
+	if strings.EqualFold(pivot, "none") {
+		return ""
+	}
+	return pivot
+}
+
+// Pairs returns the supported language pairs. Only one refresh runs at a
+// time, outside the lock; a stale list is served while it runs or if it
+// fails, and only callers without any list wait for it.
+func (c *languageCatalog) Pairs(ctx context.Context) ([]languagePair, error) {
+	c.mu.Lock()
+	if c.supported == nil || time.Since(c.fetched) >= c.ttl {
+		if c.refreshing == nil && !time.Now().Before(c.retryAt) {
+			c.refreshing = make(chan struct{})
+			go c.refresh(context.WithoutCancel(ctx), c.refreshing)
+		}
+		if c.supported == nil && c.refreshing != nil {
+			wait := c.refreshing
+			c.mu.Unlock()
+			select {
+			case <-wait:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			c.mu.Lock()
+		}
+	}
+	defer c.mu.Unlock()
+
+	if c.supported == nil {
+		return nil, c.err
+	}
+	return c.pairs, nil
+}
+
+// refresh fetches the supported pairs and closes done; after a failure the
+// next refresh is delayed
+func (c *languageCatalog) refresh(ctx context.Context, done chan struct{}) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	pairs, err := c.lister.Languages(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer close(done)
+	c.refreshing = nil
+
+	if err != nil {
+		c.failures++
+		delay := min(languageRetryMin<<min(c.failures-1, 16), languageRetryMax)
+		c.err = err
+		c.retryAt = time.Now().Add(delay)
+		log.Printf("Error refreshing supported languages, retrying in %s: %v", delay, err)
+		return
+	}
+
+	c.pairs = pairs
+	c.supported = make(map[string]bool, len(pairs))
+	for _, pair := range pairs {
+		c.supported[pairKey(pair.SourceLanguage, pair.TargetLanguage)] = true
+	}
+	c.fetched = time.Now()
+	c.err = nil
+	c.failures = 0
+	c.retryAt = time.Time{}
+}
+
+// route returns the languages a translation from sourceLang to targetLang
+// passes through: the pair itself if it is supported, otherwise through the
+// pivot language. If the supported pairs are unavailable the direct route is
+// returned, leaving the translation service to reject it.
+func (c *languageCatalog) route(ctx context.Context, sourceLang, targetLang string) ([]string, error) {
+	direct := []string{sourceLang, targetLang}
+	if _, err := c.Pairs(ctx); err != nil {
+		// Refresh failures are logged by refresh
+		return direct, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.supported[pairKey(sourceLang, targetLang)] {
+		return direct, nil
+	}
+	if c.pivot != "" && c.pivot != sourceLang && c.pivot != targetLang &&
+		c.supported[pairKey(sourceLang, c.pivot)] && c.supported[pairKey(c.pivot, targetLang)] {
+		return []string{sourceLang, c.pivot, targetLang}, nil
+	}
+	return nil, fmt.Errorf("%w %s to %s", errUnsupportedPair, sourceLang, targetLang)
+}
+
+// handleLanguages handles the /languages endpoint
+func handleLanguages(w http.ResponseWriter, r *http.Request) {
+	defer recoverFromPanic(w, r)
+
+	if r.Method != http.MethodGet {
+		writeError(w, r, methodNotAllowed())
+		return
+	}
+
+	pairs, err := languages.Pairs(r.Context())
+	if err != nil {
+		writeError(w, r, classifyError(err))
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, map[string][]languagePair{"pairs": pairs})
+}
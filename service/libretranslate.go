@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// libreTranslator is a Translator backed by a LibreTranslate compatible
+// HTTP API
+type libreTranslator struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// newLibreTranslator creates a client for the LibreTranslate API at url
+func newLibreTranslator(url, apiKey string) *libreTranslator {
+	return &libreTranslator{url: strings.TrimRight(url, "/"), apiKey: apiKey, client: &http.Client{}}
+}
+
+func (t *libreTranslator) Name() string {
+	return "libretranslate"
+}
+
+func (t *libreTranslator) Translate(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"q":       texts,
+		"source":  sourceLang,
+		"target":  targetLang,
+		"format":  "text",
+		"api_key": t.apiKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		TranslatedText []string `json:"translatedText"`
+	}
+	if err := t.do(ctx, http.MethodPost, "/translate", body, &res); err != nil {
+		return nil, err
+	}
+	return res.TranslatedText, nil
+}
+
+func (t *libreTranslator) Languages(ctx context.Context) ([]languagePair, error) {
+	var res []struct {
+		Code    string   `json:"code"`
+		Targets []string `json:"targets"`
+	}
+	if err := t.do(ctx, http.MethodGet, "/languages", nil, &res); err != nil {
+		return nil, err
+	}
+
+	var pairs []languagePair
+	for _, language := range res {
+		for _, target := range language.Targets {
+			if target != language.Code {
+				pairs = append(pairs, languagePair{SourceLanguage: language.Code, TargetLanguage: target})
+			}
+		}
+	}
+	return pairs, nil
+}
+
+// do sends a request and decodes the JSON response into out. Failures are
+// reported as gRPC statuses so they are classified like the other backends.
+func (t *libreTranslator) do(ctx context.Context, method, path string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, t.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return &backendError{service: "translation", err: status.Error(codes.Unavailable, err.Error())}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &backendError{service: "translation", err: status.Errorf(httpStatusCode(resp.StatusCode), "LibreTranslate returned %s: %s", resp.Status, message)}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding LibreTranslate response: %w", err)
+	}
+	return nil
+}
+
+// httpStatusCode maps an HTTP status from a backend to a gRPC status code
+func httpStatusCode(statusCode int) codes.Code {
+	switch {
+	case statusCode == http.StatusTooManyRequests, statusCode == http.StatusServiceUnavailable, statusCode == http.StatusBadGateway:
+		return codes.Unavailable
+	case statusCode == http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case statusCode >= 400 && statusCode < 500:
+		return codes.InvalidArgument
+	}
+	return codes.Internal
+}
@@ -2,74 +2,113 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/jackc/pgx/v5"
-	"github.com/pgvector/pgvector-go"
-	pgxvec "github.com/pgvector/pgvector-go/pgx"
-
-	embedpb "service/embeddingapi/service"
+	"service/segmenter"
 	translatepb "service/translationsapi/service"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 var (
-	conn            *pgx.Conn
-	embeddingURL    string
-	translateURL    string
-	embedConn       *grpc.ClientConn
-	translateConn   *grpc.ClientConn
-	translateClient translatepb.TranslatorClient
-	embedClient     embedpb.EmbedderClient
+	store       Store
+	embedder    Embedder
+	translators *translatorRouter
+	languages   *languageCatalog
+	retention   evictionPolicy
 )
 
-func init() {
-	// Load environment variables
-	embeddingURL = getEnv("EMBEDDING_URL")
-	translateURL = getEnv("TRANSLATE_URL")
-	connStr := getEnv("DATABASE_URL")
-
-	// Connect to the database
+// setup reads the configuration and creates the store and the translation
+// and embedding backends
+func setup() error {
+	if err := loadSimilarityThresholds(); err != nil {
+		return fmt.Errorf("invalid similarity threshold configuration: %w", err)
+	}
 	var err error
-	conn, err = pgx.Connect(context.Background(), connStr)
-	if err != nil {
-		log.Fatalf("Unable to connect to database: %v\n", err)
+	if retention, err = loadEvictionPolicy(); err != nil {
+		return fmt.Errorf("invalid cache eviction configuration: %w", err)
 	}
 
-	// Register pgvector types
-	if err := pgxvec.RegisterTypes(context.TODO(), conn); err != nil {
-		log.Fatalf("Failed to register pgvector types: %v\n", err)
+	// Connect to the database
+	store, err = newPgStore(context.Background(), getEnv("DATABASE_URL"))
+	if err != nil {
+		return fmt.Errorf("unable to connect to database: %w", err)
 	}
 
-	// Create grpc clients
-	translateConn, err = grpc.NewClient(translateURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// Create the translation and embedding backends
+	translators, err = newTranslatorRouter()
 	if err != nil {
-		log.Panicf("error connecting to translation service: %v", err)
+		return fmt.Errorf("error creating translation backends: %w", err)
 	}
-	translateClient = translatepb.NewTranslatorClient(translateConn)
+	languages = newLanguageCatalog(translators, languageCatalogTTL(), pivotLanguage())
 
-	embedConn, err = grpc.NewClient(embeddingURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	embedder, err = newEmbedder(getEnvWithDefault("EMBEDDING_BACKEND", "grpc"))
 	if err != nil {
-		log.Panicf("error connecting to embedding service: %v", err)
+		return fmt.Errorf("error creating embedding backend: %w", err)
 	}
-	embedClient = embedpb.NewEmbedderClient(embedConn)
-	log.Println("Service initialized successfully")
+	return nil
 }
 
 func main() {
-	defer conn.Close(context.Background())
-	defer translateConn.Close()
-	defer embedConn.Close()
+	reembed := flag.Bool("reembed", false, "re-embed cache entries produced by other embedding models, then exit")
+	flag.Parse()
+
+	if err := setup(); err != nil {
+		log.Fatalf("Unable to start service: %v\n", err)
+	}
+	defer store.Close()
+	defer translators.Close()
+	defer closeEmbedder(embedder)
+	log.Println("Service initialized successfully")
+
+	if *reembed {
+		if err := reembedCache(context.Background(), reembedBatchSize()); err != nil {
+			log.Fatalf("Re-embedding failed: %v\n", err)
+		}
+		return
+	}
+
+	if retention.enabled() {
+		go runEviction(context.Background(), retention)
+	}
+
+	grpcPort := getEnvWithDefault("GRPC_PORT", "50051")
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen on gRPC port %s: %v\n", grpcPort, err)
+	}
+	grpcServer := grpc.NewServer()
+	translatepb.RegisterTranslatorServer(grpcServer, &translatorServer{})
+	defer grpcServer.GracefulStop()
+	go func() {
+		log.Printf("Starting gRPC server on port %s...\n", grpcPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v\n", err)
+		}
+	}()
 
 	http.HandleFunc("/translate", handleTranslate)
+	http.HandleFunc("/translate/batch", handleTranslateBatch)
+	http.HandleFunc("/translate/stream", handleTranslateStream)
+	http.HandleFunc("/languages", handleLanguages)
+	if token := adminToken(); token != "" {
+		registerAdminHandlers(http.DefaultServeMux, token)
+	} else {
+		log.Println("ADMIN_TOKEN is not set, cache administration endpoints are disabled")
+	}
 
 	port := getEnvWithDefault("PORT", "8080")
 	log.Printf("Starting server on port %s...\n", port)
@@ -80,152 +119,499 @@ func main() {
 
 // handleTranslate handles the /translate endpoint
 func handleTranslate(w http.ResponseWriter, r *http.Request) {
-	defer recoverFromPanic(w)
+	defer recoverFromPanic(w, r)
 
 	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		writeError(w, r, methodNotAllowed())
 		return
 	}
 
-	var request struct {
-		Text           string `json:"text"`
-		SourceLanguage string `json:"source_language"`
-		TargetLanguage string `json:"target_language"`
-	}
-
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Text == "" || request.SourceLanguage == "" || request.TargetLanguage == "" {
-		http.Error(w, "Invalid or missing fields in request body", http.StatusBadRequest)
+	request, ok := decodeTranslateRequest(w, r)
+	if !ok {
 		return
 	}
 
-	responseText, err := processTranslation(request.Text, request.SourceLanguage, request.TargetLanguage)
+	result, err := processTranslation(r.Context(), request.Text, request.SourceLanguage, request.TargetLanguage, request.options())
 	if err != nil {
-		log.Printf("Error processing translation: %v", err)
-		http.Error(w, "Error processing translation", http.StatusInternalServerError)
+		writeError(w, r, classifyError(err))
 		return
 	}
 
-	writeJSONResponse(w, http.StatusOK, map[string]string{"translation": responseText})
+	response := map[string]interface{}{
+		"translation": result.Translation,
+		"distance":    result.Distance,
+		"route":       result.Route,
+	}
+	if result.GlossaryID != 0 {
+		response["glossary_id"] = result.GlossaryID
+	}
+	if result.Flagged != nil {
+		response["flagged"] = result.Flagged
+	}
+	if result.DetectedLanguage != "" {
+		response["detected_language"] = result.DetectedLanguage
+		response["detection_confidence"] = result.DetectionConfidence
+	}
+	if request.Debug {
+		if result.Candidates == nil {
+			result.Candidates = []cacheCandidate{}
+		}
+		response["candidates"] = result.Candidates
+	}
+	if request.Verbose {
+		if result.Segments == nil {
+			result.Segments = []segmentDetail{}
+		}
+		response["segments"] = result.Segments
+	}
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// translateRequest is the body of the /translate and /translate/stream
+// endpoints; an empty or "auto" source language is detected from the text
+type translateRequest struct {
+	Text           string  `json:"text"`
+	SourceLanguage string  `json:"source_language"`
+	TargetLanguage string  `json:"target_language"`
+	Tenant         string  `json:"tenant"`
+	MaxDistance    float64 `json:"max_distance"`
+	ExactOnly      bool    `json:"exact_only"`
+	Debug          bool    `json:"debug"`
+	Verbose        bool    `json:"verbose"`
 }
 
-// processTranslation handles the translation logic
-func processTranslation(text, sourceLang, targetLang string) (string, error) {
-	sentences := strings.Split(text, ". ")
-	embeddings := make([][]float32, len(sentences))
-	var translations []string
+// decodeTranslateRequest decodes and validates a translateRequest, writing an
+// invalid_request error and returning false if it is invalid
+func decodeTranslateRequest(w http.ResponseWriter, r *http.Request) (translateRequest, bool) {
+	var request translateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Text == "" || request.TargetLanguage == "" {
+		writeError(w, r, invalidRequest("invalid or missing fields in request body"))
+		return request, false
+	}
+	if request.MaxDistance < 0 || request.MaxDistance > 2 {
+		writeError(w, r, invalidRequest("max_distance must be between 0 and 2"))
+		return request, false
+	}
+	return request, true
+}
 
-	// Get embeddings for each sentence
-	for i, sentence := range sentences {
-		embedding, err := getEmbedding(sentence)
+// options returns the translation options requested
+func (r translateRequest) options() translationOptions {
+	return translationOptions{
+		MaxDistance: r.MaxDistance,
+		ExactOnly:   r.ExactOnly,
+		Debug:       r.Debug,
+		Verbose:     r.Verbose,
+		Tenant:      r.Tenant,
+	}
+}
+
+// translationOptions holds the per-request cache settings
+type translationOptions struct {
+	MaxDistance float64 // Tightens the configured similarity threshold when non-zero
+	ExactOnly   bool    // Skips the similarity search, only reusing exact matches
+	Debug       bool    // Collects the cache candidates considered for each sentence
+	Verbose     bool    // Reports how each sentence was translated
+	Tenant      string  // Selects the glossary, falling back to the default one
+	// OnStart, when set, is called once the source language is detected and
+	// the pair is known to be supported, before any sentence is translated
+	OnStart func()
+	// OnSegment, when set, is called as each sentence completes; calls are
+	// serialized but arrive in completion order rather than input order
+	OnSegment func(segmentEvent)
+}
+
+// translationResult holds the translated text and how it was produced
+type translationResult struct {
+	Translation string
+	Distance    float64 // The largest cosine distance of any cached sentence used
+	Candidates  []cacheCandidate
+	Route       []string // The languages translated through, from source to target
+	Segments    []segmentDetail
+	GlossaryID  int64            // The glossary applied, 0 for none
+	Flagged     []flaggedSegment // Sentences whose translation lost or repeated masked spans
+
+	// Set when the source language was omitted and detected from the text
+	DetectedLanguage    string
+	DetectionConfidence float64
+}
+
+// processTranslation handles the translation logic
+func processTranslation(ctx context.Context, text, sourceLang, targetLang string, opts translationOptions) (translationResult, error) {
+	var result translationResult
+	if isAutoLanguage(sourceLang) {
+		detected, err := detectSourceLanguage(text)
 		if err != nil {
-			return "", fmt.Errorf("error getting embedding: %w", err)
+			return result, err
 		}
-		embeddings[i] = embedding
+		sourceLang = detected.Language
+		result.DetectedLanguage = detected.Language
+		result.DetectionConfidence = detected.Confidence
+	}
+	route, err := languages.route(ctx, sourceLang, targetLang)
+	if err != nil {
+		return result, err
+	}
+	result.Route = route
+	if opts.OnStart != nil {
+		opts.OnStart()
 	}
 
-	// Process each sentence
-	for i, sentence := range sentences {
-		embedding := embeddings[i]
-		cachedTranslation, found, err := getFromCache(conn, sourceLang, targetLang, embedding)
-		if err != nil {
-			return "", fmt.Errorf("error accessing cache: %w", err)
+	// Do-not-translate spans are found before the text is split, so that an
+	// ICU plural or code span holding several sentences stays in one
+	segments, protected, protectedSpans := segmentMasked(sourceLang, text)
+	if len(segments) == 0 {
+		result.Translation = text
+		return result, nil
+	}
+
+	// Do-not-translate spans and glossary terms are masked so that the
+	// backends leave them alone, and restored verbatim or replaced by their
+	// mandated translations at the end; the cache keys include the glossary
+	glossary, err := findGlossary(ctx, opts.Tenant, sourceLang, targetLang)
+	if err != nil {
+		return result, err
+	}
+	if glossary != nil {
+		result.GlossaryID = glossary.ID
+	}
+	texts := make([]string, len(segments))
+	spans := make([][]string, len(segments))
+	sources := make([][]string, len(segments))
+	for i := range segments {
+		texts[i], spans[i], sources[i] = maskSentence(protected[i], protectedSpans[i], glossary)
+	}
+
+	// Each leg of the route is translated and cached on its own, the last
+	// leg translating the output of the one before it
+	legs := make([][]sentenceResult, len(route)-1)
+	start := time.Now()
+	var emitMu sync.Mutex
+	for leg := range legs {
+		legs[leg] = make([]sentenceResult, len(texts))
+		for i, text := range texts {
+			legs[leg][i] = sentenceResult{Text: text, Hash: sourceHash(text)}
 		}
 
-		if found {
-			log.Printf("Using cached translation for: %s", sentence)
-			translations = append(translations, cachedTranslation)
-		} else {
-			log.Printf("No cache found for: %s, fetching translation", sentence)
-			translation, err := getTranslation(sentence, sourceLang, targetLang)
-			if err != nil {
-				return "", fmt.Errorf("error getting translation: %w", err)
+		emit := func(int) {}
+		if leg == len(legs)-1 && opts.OnSegment != nil {
+			emit = func(i int) {
+				emitMu.Lock()
+				defer emitMu.Unlock()
+				translation, missing, duplicated := unmask(legs[leg][i].Translation, spans[i])
+				opts.OnSegment(segmentEvent{
+					Index:       i,
+					Source:      segments[i].Text,
+					Translation: translation,
+					Leading:     segments[i].Leading,
+					Trailing:    segments[i].Trailing,
+					Cached:      cachedOnAllLegs(legs, i),
+					ElapsedMs:   time.Since(start).Milliseconds(),
+					Missing:     missing,
+					Duplicated:  duplicated,
+				})
 			}
+		}
 
-			if err := saveToCache(conn, sourceLang, targetLang, embedding, translation, sentence); err != nil {
-				return "", fmt.Errorf("error saving to cache: %w", err)
-			}
-			translations = append(translations, translation)
+		if err := translateLeg(ctx, legs[leg], route[leg], route[leg+1], result.GlossaryID, opts, emit); err != nil {
+			return result, err
+		}
+		for i := range texts {
+			texts[i] = legs[leg][i].Translation
 		}
 	}
 
-	return strings.Join(translations, ". "), nil
+	// Verbose and debug output is reported unmasked; only the first leg's
+	// source holds the source glossary terms
+	restore := func(text string, spans []string) string {
+		restored, _, _ := unmask(text, spans)
+		return restored
+	}
+	for leg, sentences := range legs {
+		for i, sentence := range sentences {
+			sourceSpans := spans[i]
+			if leg == 0 {
+				sourceSpans = sources[i]
+			}
+			result.Distance = max(result.Distance, sentence.Distance)
+			for _, candidate := range sentence.Candidates {
+				candidate.SourceText = restore(candidate.SourceText, sourceSpans)
+				candidate.TargetText = restore(candidate.TargetText, spans[i])
+				result.Candidates = append(result.Candidates, candidate)
+			}
+			if opts.Verbose {
+				result.Segments = append(result.Segments, segmentDetail{
+					Index:          i,
+					SourceLanguage: route[leg],
+					TargetLanguage: route[leg+1],
+					Source:         restore(sentence.Text, sourceSpans),
+					Translation:    restore(sentence.Translation, spans[i]),
+					Cache:          sentence.Status,
+					Distance:       sentence.Distance,
+					CacheID:        sentence.CacheID,
+					LatencyMs:      sentence.Latency.Milliseconds(),
+					Backend:        sentence.Backend,
+				})
+			}
+		}
+	}
+	for i := range texts {
+		var missing, duplicated []string
+		texts[i], missing, duplicated = unmask(texts[i], spans[i])
+		if len(missing) > 0 || len(duplicated) > 0 {
+			log.Printf("Translation dropped %q and duplicated %q of: %s", missing, duplicated, segments[i].Text)
+			result.Flagged = append(result.Flagged, flaggedSegment{Index: i, Missing: missing, Duplicated: duplicated})
+		}
+	}
+	result.Translation = segmenter.Join(segments, texts)
+	return result, nil
 }
 
-// getEmbedding fetches the embedding for a given text
-func getEmbedding(text string) ([]float32, error) {
-	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
-	defer cancel()
+// translateLeg translates sentences from sourceLang to targetLang through the
+// cache entries of the glossary, calling emit with the index of each sentence
+// as it completes
+func translateLeg(ctx context.Context, sentences []sentenceResult, sourceLang, targetLang string, glossaryID int64, opts translationOptions, emit func(int)) error {
+	threshold := similarityThreshold(sourceLang, targetLang, opts.MaxDistance)
+	concurrency := translationConcurrency()
+
+	start := time.Now()
+	done := func(i int) {
+		sentences[i].Latency = time.Since(start)
+		emit(i)
+	}
+
+	// Exact repeats are served by hash without calling the embedding service
+	err := forEachConcurrently(ctx, len(sentences), concurrency, func(ctx context.Context, i int) error {
+		if err := lookupExact(ctx, &sentences[i], sourceLang, targetLang, glossaryID); err != nil {
+			return err
+		}
+		if sentences[i].Done {
+			done(i)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Embed the remaining sentences in one call
+	var pending []int
+	var texts []string
+	for i, sentence := range sentences {
+		if !sentence.Done {
+			pending = append(pending, i)
+			texts = append(texts, sentence.Text)
+		}
+	}
+	embeddings, model, err := getEmbeddings(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("error getting embedding: %w", err)
+	}
+	for j, i := range pending {
+		sentences[i].Embedding = embeddings[j]
+		sentences[i].EmbeddingModel = model
+	}
+
+	// Look up the remaining sentences by similarity concurrently
+	if !opts.ExactOnly {
+		err = forEachConcurrently(ctx, len(pending), concurrency, func(ctx context.Context, j int) error {
+			i := pending[j]
+			if err := lookupNearest(ctx, i, &sentences[i], sourceLang, targetLang, glossaryID, threshold, opts); err != nil {
+				return err
+			}
+			if sentences[i].Done {
+				done(i)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
 
-	// Create the request for embedding
-	req := &embedpb.EmbeddingRequest{
-		Text: text,
+	// Translate all cache misses in one call
+	var misses []int
+	texts = nil
+	for _, i := range pending {
+		if !sentences[i].Done {
+			log.Printf("No cache found for: %s, fetching translation", sentences[i].Text)
+			misses = append(misses, i)
+			texts = append(texts, sentences[i].Text)
+		}
+	}
+	if len(misses) == 0 {
+		return nil
 	}
-	res, err := embedClient.GenerateEmbedding(ctx, req)
+	translator := translators.For(sourceLang, targetLang)
+	fetched, err := getTranslations(ctx, translator, texts, sourceLang, targetLang)
 	if err != nil {
-		return nil, fmt.Errorf("error calling embedding service: %w", err)
+		return fmt.Errorf("error getting translation: %w", err)
 	}
+	return forEachConcurrently(ctx, len(misses), concurrency, func(ctx context.Context, j int) error {
+		sentence := &sentences[misses[j]]
+		sentence.Translation = fetched[j]
+		sentence.Done = true
+		sentence.Status = cacheMiss
+		sentence.Backend = translator.Name()
+
+		// Translations that lost or repeated a mask token are returned
+		// flagged but not cached, so the next request asks the backend again
+		if !sameMaskTokens(sentence.Text, fetched[j]) {
+			log.Printf("Not caching translation with altered mask tokens: %s", fetched[j])
+			done(misses[j])
+			return nil
+		}
+		id, err := store.Save(ctx, cacheRecord{
+			SourceLanguage: sourceLang,
+			TargetLanguage: targetLang,
+			SourceText:     sentence.Text,
+			TargetText:     fetched[j],
+			SourceHash:     sentence.Hash,
+			Embedding:      sentence.Embedding,
+			EmbeddingModel: sentence.EmbeddingModel,
+			Origin:         originMachine,
+			GlossaryID:     glossaryID,
+		})
+		if err != nil {
+			return fmt.Errorf("error saving to cache: %w", err)
+		}
+		sentence.CacheID = id
+		done(misses[j])
+		return nil
+	})
+}
 
-	return res.Embedding, nil
+// cachedOnAllLegs reports whether sentence i was served from the cache on
+// every leg of the route
+func cachedOnAllLegs(legs [][]sentenceResult, i int) bool {
+	for _, sentences := range legs {
+		if !sentences[i].Cached {
+			return false
+		}
+	}
+	return true
 }
 
-// getTranslation fetches the translation for a given text
-func getTranslation(text, sourceLang, targetLang string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
-	defer cancel()
+// sentenceResult holds a sentence as it moves through the pipeline
+type sentenceResult struct {
+	Text           string
+	Hash           string
+	Embedding      []float32
+	EmbeddingModel string // The model that produced Embedding; only its entries are compared
+	Done           bool   // Set once Translation holds the final translation
+	Cached         bool   // Set when Translation was served from the cache
+	Translation    string
+	Distance       float64
+	Candidates     []cacheCandidate
+	Status         string // How Translation was obtained: cacheExact, cacheSemantic or cacheMiss
+	CacheID        int64  // The cache row served or saved, 0 if the row was not saved
+	Backend        string
+	Latency        time.Duration // Time from the start of the leg until the sentence completed
+}
 
-	req := &translatepb.TranslationRequest{
-		Text:           text,
-		SourceLanguage: sourceLang,
-		TargetLanguage: targetLang,
+// Cache statuses of a translated sentence
+const (
+	cacheExact    = "exact"
+	cacheSemantic = "semantic"
+	cacheMiss     = "miss"
+)
+
+// cacheBackend is reported as the backend of cache hits
+const cacheBackend = "cache"
+
+// segmentDetail reports how one sentence was translated on one leg of the
+// route, returned in verbose mode
+type segmentDetail struct {
+	Index          int     `json:"index"`
+	SourceLanguage string  `json:"source_language"`
+	TargetLanguage string  `json:"target_language"`
+	Source         string  `json:"source"`
+	Translation    string  `json:"translation"`
+	Cache          string  `json:"cache"`
+	Distance       float64 `json:"distance"`
+	CacheID        int64   `json:"cache_id,omitempty"`
+	LatencyMs      int64   `json:"latency_ms"`
+	Backend        string  `json:"backend"`
+}
+
+// lookupExact serves a sentence from the cache by its source hash
+func lookupExact(ctx context.Context, sentence *sentenceResult, sourceLang, targetLang string, glossaryID int64) error {
+	entry, found, err := store.FindExact(ctx, sourceLang, targetLang, glossaryID, sentence.Hash)
+	if err != nil {
+		return fmt.Errorf("error accessing cache: %w", err)
 	}
+	if found {
+		log.Printf("Using exact cached translation for: %s", sentence.Text)
+		sentence.Translation = entry.TargetText
+		sentence.Done = true
+		sentence.Cached = true
+		sentence.Status = cacheExact
+		sentence.CacheID = entry.ID
+		sentence.Backend = cacheBackend
+	}
+	return nil
+}
 
-	res, err := translateClient.Translate(ctx, req)
+// lookupNearest serves the embedded sentence at index i from the best
+// verified cache entry within the similarity threshold, if any
+func lookupNearest(ctx context.Context, i int, sentence *sentenceResult, sourceLang, targetLang string, glossaryID int64, threshold float64, opts translationOptions) error {
+	entries, err := store.FindNearest(ctx, sourceLang, targetLang, glossaryID, sentence.EmbeddingModel, sentence.Embedding, threshold, cacheCandidateLimit())
 	if err != nil {
-		return "", fmt.Errorf("error calling translation service: %w", err)
+		return fmt.Errorf("error accessing cache: %w", err)
 	}
 
-	return res.Translation, nil
+	// Reject near matches that differ in numbers, URLs, placeholders or negation
+	cachedTranslation, selected, candidates := selectCacheHit(sourceLang, i, sentence.Text, entries)
+	if opts.Debug {
+		sentence.Candidates = candidates
+	}
+	if selected != nil {
+		log.Printf("Using cached translation for: %s", sentence.Text)
+		if err := store.RecordHit(ctx, selected.ID); err != nil {
+			return fmt.Errorf("error accessing cache: %w", err)
+		}
+		sentence.Translation = cachedTranslation
+		sentence.Distance = selected.Distance
+		sentence.Done = true
+		sentence.Cached = true
+		sentence.Status = cacheSemantic
+		sentence.CacheID = selected.ID
+		sentence.Backend = cacheBackend
+	}
+	return nil
 }
 
-// getFromCache retrieves a cached translation from the database
-func getFromCache(conn *pgx.Conn, sourceLang, targetLang string, embedding []float32) (string, bool, error) {
-	query := `
-        SELECT target_text
-        FROM translations_cache
-        WHERE source_language = $1
-        AND target_language = $2
-        AND embedding <=> $3 <= 0.1
-        LIMIT 1;
-    `
-
-	var targetText string
-	err := conn.QueryRow(context.TODO(), query, sourceLang, targetLang, pgvector.NewVector(embedding)).Scan(&targetText)
+// getTranslations fetches the translations for texts of one language pair in
+// a single call to translator; the timeout grows with the number of texts
+func getTranslations(ctx context.Context, translator Translator, texts []string, sourceLang, targetLang string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second+time.Duration(len(texts))*time.Second)
+	defer cancel()
+
+	translations, err := translator.Translate(ctx, texts, sourceLang, targetLang)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return "", false, nil
-		}
-		return "", false, err
+		return nil, err
+	}
+	if len(translations) != len(texts) {
+		return nil, fmt.Errorf("%s backend returned %d translations for %d texts", translator.Name(), len(translations), len(texts))
 	}
-	return targetText, true, nil
+
+	return translations, nil
 }
 
-// saveToCache saves a translation to the database
-func saveToCache(conn *pgx.Conn, sourceLang, targetLang string, embedding []float32, targetText, sourceText string) error {
-	query := `
-        INSERT INTO translations_cache (source_language, target_language, embedding, target_text, source_text)
-        VALUES ($1, $2, $3, $4, $5);
-    `
-	_, err := conn.Exec(context.TODO(), query, sourceLang, targetLang, pgvector.NewVector(embedding), targetText, sourceText)
-	return err
+// sourceHash returns the hash of the normalized source text used for exact
+// cache lookups; normalization applies NFC and collapses whitespace
+func sourceHash(text string) string {
+	normalized := strings.Join(strings.Fields(norm.NFC.String(text)), " ")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
 }
 
 // Utility Functions
 
 // recoverFromPanic handles panics and sends an error response
-func recoverFromPanic(w http.ResponseWriter) {
-	if r := recover(); r != nil {
-		log.Printf("Recovered from panic: %v", r)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+func recoverFromPanic(w http.ResponseWriter, r *http.Request) {
+	if p := recover(); p != nil {
+		writeError(w, r, internalError(fmt.Errorf("recovered from panic: %v", p)))
 	}
 }
 
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryStore is an in-memory Store holding the cache entries and glossaries
+// used by the translation pipeline; other methods are not implemented
+type memoryStore struct {
+	Store
+
+	mu         sync.Mutex
+	records    []cacheRecord
+	hits       map[int64]int
+	glossaries []glossary
+}
+
+func (s *memoryStore) FindExact(ctx context.Context, sourceLang, targetLang string, glossaryID int64, hash string) (cacheEntry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, r := range s.records {
+		if r.SourceLanguage == sourceLang && r.TargetLanguage == targetLang && r.GlossaryID == glossaryID && r.SourceHash == hash {
+			return cacheEntry{ID: int64(i + 1), SourceText: r.SourceText, TargetText: r.TargetText, Origin: r.Origin, Priority: r.Priority}, true, nil
+		}
+	}
+	return cacheEntry{}, false, nil
+}
+
+func (s *memoryStore) FindNearest(ctx context.Context, sourceLang, targetLang string, glossaryID int64, model string, embedding []float32, threshold float64, limit int) ([]cacheEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var entries []cacheEntry
+	for i, r := range s.records {
+		if r.SourceLanguage != sourceLang || r.TargetLanguage != targetLang || r.GlossaryID != glossaryID || r.EmbeddingModel != model {
+			continue
+		}
+		if distance := cosineDistance(embedding, r.Embedding); distance <= threshold {
+			entries = append(entries, cacheEntry{ID: int64(i + 1), SourceText: r.SourceText, TargetText: r.TargetText, Distance: distance, Origin: r.Origin, Priority: r.Priority})
+		}
+	}
+	slices.SortFunc(entries, func(a, b cacheEntry) int {
+		return cmp.Compare(a.Distance, b.Distance)
+	})
+	return entries[:min(limit, len(entries))], nil
+}
+
+func (s *memoryStore) Save(ctx context.Context, record cacheRecord) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return int64(len(s.records)), nil
+}
+
+func (s *memoryStore) RecordHit(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hits[id]++
+	return nil
+}
+
+func (s *memoryStore) FindGlossary(ctx context.Context, tenant, sourceLang, targetLang string) (glossary, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, g := range s.glossaries {
+		if g.Tenant == tenant && g.SourceLanguage == sourceLang && g.TargetLanguage == targetLang {
+			g.Terms = slices.Clone(g.Terms)
+			return g, true, nil
+		}
+	}
+	return glossary{}, false, nil
+}
+
+func cosineDistance(a, b []float32) float64 {
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	return 1 - dot/math.Sqrt(normA*normB)
+}
+
+// setupPipeline points the service at a memory store, the hashing embedder
+// and the fake translator
+func setupPipeline(t *testing.T, fake *fakeTranslator) *memoryStore {
+	t.Helper()
+	if err := loadSimilarityThresholds(); err != nil {
+		t.Fatal(err)
+	}
+	memory := &memoryStore{hits: make(map[int64]int)}
+	store = memory
+	embedder = newHashingEmbedder()
+	translators = &translatorRouter{fallback: fake, pairs: map[string]Translator{}, backends: []Translator{fake}}
+	languages = newLanguageCatalog(translators, time.Minute, "en")
+	t.Cleanup(func() {
+		store, embedder, translators, languages = nil, nil, nil, nil
+	})
+	return memory
+}
+
+func TestProcessTranslationCaches(t *testing.T) {
+	fake := &fakeTranslator{}
+	memory := setupPipeline(t, fake)
+	ctx := context.Background()
+
+	result, err := processTranslation(ctx, "Hello world. Your order ships within 3 days from our main warehouse.", "en", "es", translationOptions{Verbose: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[es] Hello world. [es] Your order ships within 3 days from our main warehouse."; result.Translation != want {
+		t.Errorf("translation = %q, want %q", result.Translation, want)
+	}
+	if fake.Calls() != 1 || len(memory.records) != 2 {
+		t.Fatalf("got %d backend calls and %d cached entries, want 1 and 2", fake.Calls(), len(memory.records))
+	}
+
+	// Repeats are served by hash, and sentences differing only in numbers by
+	// similarity with the numbers patched
+	result, err = processTranslation(ctx, "Hello world. Your order ships within 5 days from our main warehouse.", "en", "es", translationOptions{Verbose: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[es] Hello world. [es] Your order ships within 5 days from our main warehouse."; result.Translation != want {
+		t.Errorf("translation = %q, want %q", result.Translation, want)
+	}
+	if fake.Calls() != 1 {
+		t.Errorf("got %d backend calls, want 1", fake.Calls())
+	}
+	var statuses []string
+	for _, segment := range result.Segments {
+		statuses = append(statuses, segment.Cache)
+	}
+	if want := []string{cacheExact, cacheSemantic}; !slices.Equal(statuses, want) {
+		t.Errorf("cache statuses = %q, want %q", statuses, want)
+	}
+}
+
+func TestProcessTranslationMasks(t *testing.T) {
+	fake := &fakeTranslator{}
+	memory := setupPipeline(t, fake)
+	memory.glossaries = []glossary{{
+		ID:             1,
+		SourceLanguage: "en",
+		TargetLanguage: "es",
+		Terms:          []glossaryTerm{{SourceTerm: "Workspace", TargetTerm: "Espacio"}},
+	}}
+
+	result, err := processTranslation(context.Background(), "Open the Workspace with `code .` now.", "en", "es", translationOptions{Verbose: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[es] Open the Espacio with `code .` now."; result.Translation != want {
+		t.Errorf("translation = %q, want %q", result.Translation, want)
+	}
+	if result.GlossaryID != 1 || len(result.Flagged) != 0 {
+		t.Errorf("got glossary %d and flagged %v, want glossary 1 and none flagged", result.GlossaryID, result.Flagged)
+	}
+	if len(result.Segments) != 1 || result.Segments[0].Source != "Open the Workspace with `code .` now." || result.Segments[0].Translation != result.Translation {
+		t.Errorf("segments = %+v, want the unmasked sentence and translation", result.Segments)
+	}
+	if len(memory.records) != 1 || strings.Contains(memory.records[0].SourceText, "Workspace") || memory.records[0].GlossaryID != 1 {
+		t.Errorf("cached %+v, want one masked entry of glossary 1", memory.records)
+	}
+}
+
+func TestProcessTranslationKeepsSpansWhole(t *testing.T) {
+	fake := &fakeTranslator{}
+	memory := setupPipeline(t, fake)
+
+	plural := "{count, plural, one {You have # file. Delete it?} other {You have # files. Delete them?}}"
+	text := "Heads up. " + plural + " Run `make. Then` now."
+	result, err := processTranslation(context.Background(), text, "en", "es", translationOptions{Verbose: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[es] Heads up. [es] " + plural + " Run `make. Then` now."; result.Translation != want {
+		t.Errorf("translation = %q, want %q", result.Translation, want)
+	}
+	if len(result.Segments) != 2 || result.Segments[1].Source != plural+" Run `make. Then` now." || len(result.Flagged) != 0 {
+		t.Errorf("segments = %+v and flagged %v, want the plural and code span in the second sentence", result.Segments, result.Flagged)
+	}
+	if !slices.ContainsFunc(memory.records, func(r cacheRecord) bool { return r.SourceText == "⟦0⟧ Run ⟦1⟧ now." }) {
+		var cached []string
+		for _, record := range memory.records {
+			cached = append(cached, record.SourceText)
+		}
+		t.Errorf("cached %q, want the second sentence cached with tokens numbered from 0", cached)
+	}
+}
+
+func TestProcessTranslationErrors(t *testing.T) {
+	fake := &fakeTranslator{Pairs: []languagePair{{SourceLanguage: "en", TargetLanguage: "es"}}}
+	setupPipeline(t, fake)
+	ctx := context.Background()
+
+	if _, err := processTranslation(ctx, "Hello world.", "de", "fr", translationOptions{}); !errors.Is(err, errUnsupportedPair) {
+		t.Errorf("unsupported pair: got error %v, want %v", err, errUnsupportedPair)
+	}
+
+	// "una" is both Spanish and Italian, so the guess is not trusted
+	if _, err := processTranslation(ctx, "Una casa.", "auto", "es", translationOptions{}); !errors.Is(err, errUndetectedLanguage) {
+		t.Errorf("ambiguous language: got error %v, want %v", err, errUndetectedLanguage)
+	}
+
+	fake.Err = errors.New("backend down")
+	if _, err := processTranslation(ctx, "Hello world.", "en", "es", translationOptions{}); !errors.Is(err, fake.Err) {
+		t.Errorf("backend failure: got error %v, want %v", err, fake.Err)
+	}
+}
+
+func TestProcessBatchDetectsLanguage(t *testing.T) {
+	setupPipeline(t, &fakeTranslator{})
+
+	results := processBatch(context.Background(), []batchItem{
+		{ID: "detected", Text: "Save changes", TargetLanguage: "es"},
+		{ID: "given", Text: "Save changes", SourceLanguage: "en", TargetLanguage: "es"},
+	})
+	if got := results[0]; got.Error != "" || got.DetectedLanguage != "en" || got.DetectionConfidence < minDetectionConfidence() {
+		t.Errorf("detected item = %+v, want en detected", got)
+	}
+	if got := results[1]; got.Error != "" || got.DetectedLanguage != "" {
+		t.Errorf("given item = %+v, want no detection", got)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"service/segmenter"
+)
+
+// Spans of a sentence that must not be translated are replaced by numbered
+// tokens before the sentence is cached, embedded and translated, and the
+// tokens are replaced back afterwards. Tokens are numbered per sentence so
+// that masked sentences share cache entries across requests.
+
+// maskTokenPattern matches a mask token, tolerating spaces a translation
+// backend inserted inside it
+var maskTokenPattern = regexp.MustCompile(`⟦\s*(\d+)\s*⟧`)
+
+// maskToken returns the token standing in for the masked span n
+func maskToken(n int) string {
+	return "⟦" + strconv.Itoa(n) + "⟧"
+}
+
+// segmentMasked splits text into sentences after masking its do-not-translate
+// spans, so that spans holding sentence terminators, such as ICU plurals
+// whose messages are full sentences or code spans, are never split. The
+// segments keep the unmasked sentences; the masked sentences are returned
+// with the spans of their tokens, renumbered per sentence.
+func segmentMasked(lang, text string) ([]segmenter.Segment, []string, [][]string) {
+	masked, spans := protectSpans(text)
+	segments := segmenter.For(lang).Segment(masked)
+	texts := make([]string, len(segments))
+	sentenceSpans := make([][]string, len(segments))
+	for i, segment := range segments {
+		texts[i], sentenceSpans[i] = renumberTokens(segment.Text, spans)
+		segments[i].Text, _, _ = unmask(segment.Text, spans)
+	}
+	return segments, texts, sentenceSpans
+}
+
+// renumberTokens renumbers the mask tokens of a sentence from 0 in order of
+// appearance, returning the sentence and the spans its tokens stand for
+func renumberTokens(sentence string, spans []string) (string, []string) {
+	var renumbered []string
+	sentence = maskTokenPattern.ReplaceAllStringFunc(sentence, func(token string) string {
+		n, err := strconv.Atoi(maskTokenPattern.FindStringSubmatch(token)[1])
+		if err != nil || n >= len(spans) {
+			return token
+		}
+		renumbered = append(renumbered, spans[n])
+		return maskToken(len(renumbered) - 1)
+	})
+	return sentence, renumbered
+}
+
+// maskSentence masks the terms of the glossary, if any, in a sentence whose
+// do-not-translate spans are already masked. It returns the masked sentence,
+// the spans its tokens are replaced by in translations, and the source text
+// each token stands for, which differs from the span for glossary terms.
+func maskSentence(sentence string, spans []string, g *glossary) (string, []string, []string) {
+	sources := slices.Clone(spans)
+	if g != nil {
+		sentence, spans, sources = g.mask(sentence, spans, sources)
+	}
+	return sentence, spans, sources
+}
+
+// flaggedSegment reports a sentence whose translation dropped or repeated
+// masked spans
+type flaggedSegment struct {
+	Index      int      `json:"index"`
+	Missing    []string `json:"missing,omitempty"`
+	Duplicated []string `json:"duplicated,omitempty"`
+}
+
+// unmask replaces the mask tokens of text with spans, returning the spans
+// whose token was missing from text and those whose token appeared more than
+// once
+func unmask(text string, spans []string) (string, []string, []string) {
+	if len(spans) == 0 {
+		return text, nil, nil
+	}
+	seen := make([]int, len(spans))
+	restored := maskTokenPattern.ReplaceAllStringFunc(text, func(token string) string {
+		n, err := strconv.Atoi(maskTokenPattern.FindStringSubmatch(token)[1])
+		if err != nil || n >= len(spans) {
+			return token
+		}
+		seen[n]++
+		return spans[n]
+	})
+
+	var missing, duplicated []string
+	for n, count := range seen {
+		switch {
+		case count == 0:
+			missing = append(missing, spans[n])
+		case count > 1:
+			duplicated = append(duplicated, spans[n])
+		}
+	}
+	return restored, missing, duplicated
+}
+
+// sameMaskTokens reports whether a translation holds every mask token of its
+// source exactly as often
+func sameMaskTokens(source, translation string) bool {
+	tokens := func(text string) []string {
+		var numbers []string
+		for _, match := range maskTokenPattern.FindAllStringSubmatch(text, -1) {
+			numbers = append(numbers, match[1])
+		}
+		slices.Sort(numbers)
+		return numbers
+	}
+	return slices.Equal(tokens(source), tokens(translation))
+}
+
+// maskTranslation replaces the first remaining occurrence of each span in a
+// translation with its mask token, so that a translation supplied for a
+// sentence can be cached with the masked sentence
+func maskTranslation(translation string, spans []string) (string, error) {
+	for n, span := range spans {
+		i := strings.Index(translation, span)
+		if i < 0 {
+			return translation, fmt.Errorf("target_text must contain %q", span)
+		}
+		translation = translation[:i] + maskToken(n) + translation[i+len(span):]
+	}
+	return translation, nil
+}
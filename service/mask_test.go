@@ -0,0 +1,104 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUnmask(t *testing.T) {
+	spans := []string{"{name}", "`make`"}
+	tests := []struct {
+		name           string
+		text           string
+		want           string
+		wantMissing    []string
+		wantDuplicated []string
+	}{
+		{"all tokens", "Hola ⟦0⟧, ejecuta ⟦1⟧.", "Hola {name}, ejecuta `make`.", nil, nil},
+		{"reordered tokens", "Ejecuta ⟦1⟧, ⟦0⟧.", "Ejecuta `make`, {name}.", nil, nil},
+		{"spaces inside tokens", "Hola ⟦ 0 ⟧, ejecuta ⟦1 ⟧.", "Hola {name}, ejecuta `make`.", nil, nil},
+		{"dropped token", "Hola ⟦0⟧.", "Hola {name}.", []string{"`make`"}, nil},
+		{"duplicated token", "Hola ⟦0⟧ ⟦0⟧, ejecuta ⟦1⟧.", "Hola {name} {name}, ejecuta `make`.", nil, []string{"{name}"}},
+		{"unknown token", "Hola ⟦0⟧ ⟦7⟧ ⟦1⟧.", "Hola {name} ⟦7⟧ `make`.", nil, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, missing, duplicated := unmask(test.text, spans)
+			if got != test.want || !slices.Equal(missing, test.wantMissing) || !slices.Equal(duplicated, test.wantDuplicated) {
+				t.Errorf("unmask(%q) = %q, %q, %q; want %q, %q, %q", test.text, got, missing, duplicated, test.want, test.wantMissing, test.wantDuplicated)
+			}
+		})
+	}
+
+	if got, _, _ := unmask("Hola ⟦0⟧.", nil); got != "Hola ⟦0⟧." {
+		t.Errorf("unmask without spans = %q, want the text unchanged", got)
+	}
+}
+
+func TestSameMaskTokens(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		translation string
+		want        bool
+	}{
+		{"same tokens", "Open ⟦0⟧ with ⟦1⟧.", "Abre ⟦0⟧ con ⟦1⟧.", true},
+		{"reordered tokens", "Open ⟦0⟧ with ⟦1⟧.", "Con ⟦1⟧, abre ⟦0⟧.", true},
+		{"spaced token", "Open ⟦0⟧.", "Abre ⟦ 0 ⟧.", true},
+		{"no tokens", "Open it.", "Ábrelo.", true},
+		{"dropped token", "Open ⟦0⟧ with ⟦1⟧.", "Abre ⟦0⟧.", false},
+		{"duplicated token", "Open ⟦0⟧.", "Abre ⟦0⟧ y ⟦0⟧.", false},
+		{"replaced token", "Open ⟦0⟧.", "Abre ⟦1⟧.", false},
+	}
+	for _, test := range tests {
+		if got := sameMaskTokens(test.source, test.translation); got != test.want {
+			t.Errorf("%s: sameMaskTokens(%q, %q) = %v, want %v", test.name, test.source, test.translation, got, test.want)
+		}
+	}
+}
+
+func TestMaskTranslation(t *testing.T) {
+	tests := []struct {
+		name        string
+		translation string
+		spans       []string
+		want        string
+		wantErr     bool
+	}{
+		{"masks each span", "Hola {name}, ejecuta `make`.", []string{"{name}", "`make`"}, "Hola ⟦0⟧, ejecuta ⟦1⟧.", false},
+		{"spans in another order", "Ejecuta `make`, {name}.", []string{"{name}", "`make`"}, "Ejecuta ⟦1⟧, ⟦0⟧.", false},
+		{"repeated span masked once per token", "{n} y {n}", []string{"{n}", "{n}"}, "⟦0⟧ y ⟦1⟧", false},
+		{"extra occurrence kept", "{n} y {n}", []string{"{n}"}, "⟦0⟧ y {n}", false},
+		{"missing span", "Hola amigo.", []string{"{name}"}, "", true},
+		{"no spans", "Hola.", nil, "Hola.", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := maskTranslation(test.translation, test.spans)
+			if (err != nil) != test.wantErr || (!test.wantErr && got != test.want) {
+				t.Errorf("maskTranslation(%q, %q) = %q, %v; want %q, error %v", test.translation, test.spans, got, err, test.want, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestSegmentMasked(t *testing.T) {
+	plural := "{count, plural, one {You have # file. Delete it?} other {You have # files. Delete them?}}"
+	text := "Hi {name}. " + plural + " Run `make. Then` now.\nBye %s."
+	segments, masked, spans := segmentMasked("en", text)
+
+	var sources []string
+	for _, segment := range segments {
+		sources = append(sources, segment.Text)
+	}
+	if want := []string{"Hi {name}.", plural + " Run `make. Then` now.", "Bye %s."}; !slices.Equal(sources, want) {
+		t.Errorf("sentences = %q, want %q", sources, want)
+	}
+	if want := []string{"Hi ⟦0⟧.", "⟦0⟧ Run ⟦1⟧ now.", "Bye ⟦0⟧."}; !slices.Equal(masked, want) {
+		t.Errorf("masked sentences = %q, want %q", masked, want)
+	}
+	want := [][]string{{"{name}"}, {plural, "`make. Then`"}, {"%s"}}
+	if !slices.EqualFunc(spans, want, slices.Equal) {
+		t.Errorf("spans = %q, want %q", spans, want)
+	}
+}
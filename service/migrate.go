@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// migrationLock is the advisory lock key held while migrating, so that
+// instances starting together do not migrate concurrently
+const migrationLock = 72201
+
+// migrationBatchSize is the number of entries whose source hash is filled in
+// per round trip
+const migrationBatchSize = 1000
+
+// schemaMigrations bring a database created by an older init.sql up to the
+// current schema, or create it; each statement is a no-op once applied
+var schemaMigrations = []string{
+	`CREATE EXTENSION IF NOT EXISTS vector`,
+	`CREATE TABLE IF NOT EXISTS translations_cache (
+		id SERIAL PRIMARY KEY,
+		source_language text NOT NULL,
+		target_language text NOT NULL,
+		source_text text NOT NULL,
+		target_text text NOT NULL,
+		embedding vector
+	)`,
+
+	// The embedding column no longer has a fixed dimension, which the old
+	// similarity index requires
+	`DROP INDEX IF EXISTS idx_translations_cache_embedding`,
+	`DO $$
+	BEGIN
+		IF (SELECT atttypmod FROM pg_attribute
+			WHERE attrelid = 'translations_cache'::regclass AND attname = 'embedding') > 0 THEN
+			ALTER TABLE translations_cache ALTER COLUMN embedding TYPE vector;
+		END IF;
+	END $$`,
+
+	`ALTER TABLE translations_cache ADD COLUMN IF NOT EXISTS source_hash text`,
+	`ALTER TABLE translations_cache ADD COLUMN IF NOT EXISTS glossary_id integer NOT NULL DEFAULT 0`,
+
+	// Existing entries have no recorded model, so the re-embedding job
+	// treats them as stale
+	`DO $$
+	BEGIN
+		IF NOT EXISTS (SELECT FROM pg_attribute
+			WHERE attrelid = 'translations_cache'::regclass AND attname = 'embedding_model' AND NOT attisdropped) THEN
+			ALTER TABLE translations_cache ADD COLUMN embedding_model text NOT NULL DEFAULT '';
+			ALTER TABLE translations_cache ALTER COLUMN embedding_model DROP DEFAULT;
+			ALTER TABLE translations_cache ADD COLUMN embedding_dimensions integer NOT NULL DEFAULT 0;
+			ALTER TABLE translations_cache ALTER COLUMN embedding_dimensions DROP DEFAULT;
+			UPDATE translations_cache SET embedding_dimensions = vector_dims(embedding) WHERE embedding IS NOT NULL;
+		END IF;
+	END $$`,
+
+	`ALTER TABLE translations_cache ADD COLUMN IF NOT EXISTS source text NOT NULL DEFAULT 'machine' CHECK (source IN ('machine', 'human', 'imported'))`,
+	`ALTER TABLE translations_cache ADD COLUMN IF NOT EXISTS priority integer NOT NULL DEFAULT 0`,
+	`ALTER TABLE translations_cache ADD COLUMN IF NOT EXISTS created_at timestamptz NOT NULL DEFAULT now()`,
+	`ALTER TABLE translations_cache ADD COLUMN IF NOT EXISTS last_hit_at timestamptz`,
+	`ALTER TABLE translations_cache ADD COLUMN IF NOT EXISTS hit_count integer NOT NULL DEFAULT 0`,
+
+	`CREATE INDEX IF NOT EXISTS idx_translations_cache_embedding_model ON translations_cache (embedding_model)`,
+	`CREATE INDEX IF NOT EXISTS idx_translations_cache_created_at ON translations_cache (source_language, target_language, created_at)`,
+	`CREATE INDEX IF NOT EXISTS idx_translations_cache_embedding_384 ON translations_cache USING ivfflat ((embedding::vector(384)) vector_cosine_ops) WITH (lists = 100) WHERE embedding_dimensions = 384`,
+
+	`CREATE TABLE IF NOT EXISTS glossaries (
+		id SERIAL PRIMARY KEY,
+		tenant text NOT NULL DEFAULT '',
+		source_language text NOT NULL,
+		target_language text NOT NULL,
+		created_at timestamptz NOT NULL DEFAULT now(),
+		updated_at timestamptz NOT NULL DEFAULT now()
+	)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_glossaries_pair ON glossaries (tenant, source_language, target_language)`,
+	`CREATE TABLE IF NOT EXISTS glossary_terms (
+		glossary_id integer NOT NULL REFERENCES glossaries (id) ON DELETE CASCADE,
+		source_term text NOT NULL,
+		target_term text NOT NULL,
+		ignore_case boolean NOT NULL DEFAULT false,
+		PRIMARY KEY (glossary_id, source_term)
+	)`,
+}
+
+// sourceHashMigrations make the source hash unique per pair and glossary once
+// it is filled in, keeping the oldest of duplicated entries; they only run
+// while the column is nullable
+var sourceHashMigrations = []string{
+	`DELETE FROM translations_cache a USING translations_cache b
+	WHERE a.source_language = b.source_language AND a.target_language = b.target_language
+	AND a.glossary_id = b.glossary_id AND a.source_hash = b.source_hash AND a.id > b.id`,
+	`ALTER TABLE translations_cache ALTER COLUMN source_hash SET NOT NULL`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_translations_cache_source_hash ON translations_cache (source_language, target_language, glossary_id, source_hash)`,
+}
+
+// migrateSchema upgrades the schema of an existing database in one
+// transaction. It runs before the statements are prepared, since they refer
+// to the new columns.
+func migrateSchema(ctx context.Context, conn *pgx.Conn) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLock); err != nil {
+		return fmt.Errorf("error locking schema: %w", err)
+	}
+	for _, sql := range schemaMigrations {
+		if _, err := tx.Exec(ctx, sql); err != nil {
+			return fmt.Errorf("error migrating schema: %w", err)
+		}
+	}
+
+	var nullable bool
+	err = tx.QueryRow(ctx, `SELECT NOT attnotnull FROM pg_attribute
+		WHERE attrelid = 'translations_cache'::regclass AND attname = 'source_hash'`).Scan(&nullable)
+	if err != nil {
+		return fmt.Errorf("error migrating schema: %w", err)
+	}
+	if !nullable {
+		return tx.Commit(ctx)
+	}
+	if err := backfillSourceHashes(ctx, tx); err != nil {
+		return fmt.Errorf("error filling in source hashes: %w", err)
+	}
+	for _, sql := range sourceHashMigrations {
+		if _, err := tx.Exec(ctx, sql); err != nil {
+			return fmt.Errorf("error migrating schema: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// backfillSourceHashes computes the source hash of entries cached before it
+// was stored; it is computed in Go since it depends on Unicode normalization
+func backfillSourceHashes(ctx context.Context, tx pgx.Tx) error {
+	for {
+		rows, err := tx.Query(ctx, "SELECT id, source_text FROM translations_cache WHERE source_hash IS NULL ORDER BY id LIMIT $1", migrationBatchSize)
+		if err != nil {
+			return err
+		}
+		batch := &pgx.Batch{}
+		for rows.Next() {
+			var id int64
+			var sourceText string
+			if err := rows.Scan(&id, &sourceText); err != nil {
+				rows.Close()
+				return err
+			}
+			batch.Queue("UPDATE translations_cache SET source_hash = $2 WHERE id = $1", id, sourceHash(sourceText))
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if batch.Len() == 0 {
+			return nil
+		}
+		if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+			return err
+		}
+	}
+}
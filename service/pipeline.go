@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// translationConcurrency returns how many sentences of a request are
+// processed at once
+func translationConcurrency() int {
+	limit, err := strconv.Atoi(getEnvWithDefault("TRANSLATION_CONCURRENCY", "8"))
+	if err != nil || limit <= 0 {
+		return 8
+	}
+	return limit
+}
+
+// forEachConcurrently calls fn for every index in [0, n) from at most limit
+// goroutines. The first error cancels the context passed to the remaining
+// calls and is returned; cancelling ctx, such as when the client disconnects,
+// stops work that has not started yet.
+func forEachConcurrently(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	indexes := make(chan int)
+	for range min(n, limit) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := fn(ctx, i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range n {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
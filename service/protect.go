@@ -0,0 +1,103 @@
+package main
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Patterns of do-not-translate spans; ICU arguments are found by
+// protectedBraces since they can nest
+var (
+	codeSpanPattern    = regexp.MustCompile("(?is)``.+?``|`[^`]+`|<code>.*?</code>")
+	printfPattern      = regexp.MustCompile(`%(?:\d+\$)?[-+#0]*(?:\d+|\*)?(?:\.(?:\d+|\*))?(?:hh|h|ll|l|L|q|j|z|t)?[sdiufFeEgGxXoaAcpqvTtb@%]`)
+	htmlEntityPattern  = regexp.MustCompile(`&(?:[A-Za-z][A-Za-z0-9]{1,31}|#[0-9]{1,7}|#[xX][0-9A-Fa-f]{1,6});`)
+	icuArgumentPattern = regexp.MustCompile(`^\s*[\w.-]+\s*(?:,[\s\S]*)?$`)
+)
+
+// protectSpans replaces the do-not-translate spans of a sentence, code spans,
+// ICU and template placeholders, printf verbs and HTML entities, with mask
+// tokens and returns the masked sentence with the spans the tokens stand for
+func protectSpans(sentence string) (string, []string) {
+	var locations [][]int
+	for _, pattern := range []*regexp.Regexp{codeSpanPattern, printfPattern, htmlEntityPattern} {
+		locations = append(locations, pattern.FindAllStringIndex(sentence, -1)...)
+	}
+	locations = append(locations, protectedBraces(sentence)...)
+	if len(locations) == 0 {
+		return sentence, nil
+	}
+
+	// Overlapping spans resolve to the one starting first, then the longest,
+	// so that placeholders inside a code span stay part of it
+	slices.SortFunc(locations, func(a, b []int) int {
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+		return b[1] - a[1]
+	})
+	var b strings.Builder
+	var spans []string
+	end := 0
+	for _, loc := range locations {
+		if loc[0] < end {
+			continue
+		}
+		b.WriteString(sentence[end:loc[0]])
+		b.WriteString(maskToken(len(spans)))
+		spans = append(spans, sentence[loc[0]:loc[1]])
+		end = loc[1]
+	}
+	b.WriteString(sentence[end:])
+	return b.String(), spans
+}
+
+// protectedBraces returns the locations of the balanced brace groups of a
+// sentence that are ICU arguments such as "{count}" or "{count, plural, one
+// {# file} other {# files}}", template placeholders such as "{{name}}", or
+// "${name}". ICU arguments are kept whole, so the messages nested in plural
+// and select arguments are not translated.
+func protectedBraces(sentence string) [][]int {
+	var locations [][]int
+	for start := 0; start < len(sentence); start++ {
+		if sentence[start] != '{' {
+			continue
+		}
+		end := matchingBrace(sentence, start)
+		if end < 0 {
+			continue
+		}
+		content := sentence[start+1 : end]
+		if inner, ok := strings.CutPrefix(content, "{"); ok {
+			content = strings.TrimSuffix(inner, "}")
+		}
+		if !icuArgumentPattern.MatchString(content) {
+			continue
+		}
+		from := start
+		if start > 0 && sentence[start-1] == '$' {
+			from--
+		}
+		locations = append(locations, []int{from, end + 1})
+		start = end
+	}
+	return locations
+}
+
+// matchingBrace returns the index of the brace closing the one at start, or
+// -1 if it is not closed
+func matchingBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestProtectSpans(t *testing.T) {
+	tests := []struct {
+		name      string
+		sentence  string
+		want      string
+		wantSpans []string
+	}{
+		{"nothing to protect", "Hello world.", "Hello world.", nil},
+		{"icu argument", "Hello {name}, welcome.", "Hello ⟦0⟧, welcome.", []string{"{name}"}},
+		{"nested icu plural", "You have {count, plural, one {# file} other {# files}} left.", "You have ⟦0⟧ left.", []string{"{count, plural, one {# file} other {# files}}"}},
+		{"icu select with nested argument", "{gender, select, female {She} other {They}} invited {guest}.", "⟦0⟧ invited ⟦1⟧.", []string{"{gender, select, female {She} other {They}}", "{guest}"}},
+		{"template placeholder", "Hi {{ user.name }}!", "Hi ⟦0⟧!", []string{"{{ user.name }}"}},
+		{"dollar placeholder", "Total: ${amount} due.", "Total: ⟦0⟧ due.", []string{"${amount}"}},
+		{"braces that are not arguments", "Use {a b c} here.", "Use {a b c} here.", nil},
+		{"unbalanced brace", "Open { and {name}.", "Open { and ⟦0⟧.", []string{"{name}"}},
+		{"printf verbs", "Copied %d of %s in %.2f s, 100%% done.", "Copied ⟦0⟧ of ⟦1⟧ in ⟦2⟧ s, 100⟦3⟧ done.", []string{"%d", "%s", "%.2f", "%%"}},
+		{"positional printf verb", "Hello %1$s, you are %2$d.", "Hello ⟦0⟧, you are ⟦1⟧.", []string{"%1$s", "%2$d"}},
+		{"html entities", "Tom &amp; Jerry &#39;s &#x1F600; show.", "Tom ⟦0⟧ Jerry ⟦1⟧s ⟦2⟧ show.", []string{"&amp;", "&#39;", "&#x1F600;"}},
+		{"not an entity", "Fish & chips &nbsp ok.", "Fish & chips &nbsp ok.", nil},
+		{"code spans", "Run `make build` or ``a `b` c`` or <code>ls -la</code>.", "Run ⟦0⟧ or ⟦1⟧ or ⟦2⟧.", []string{"`make build`", "``a `b` c``", "<code>ls -la</code>"}},
+		{"placeholder inside code span", "Set `{name} = %s` first.", "Set ⟦0⟧ first.", []string{"`{name} = %s`"}},
+		{"verb inside icu argument", "{count, plural, one {%d file} other {%d files}} found.", "⟦0⟧ found.", []string{"{count, plural, one {%d file} other {%d files}}"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, spans := protectSpans(test.sentence)
+			if got != test.want || !slices.Equal(spans, test.wantSpans) {
+				t.Errorf("protectSpans(%q) = %q, %q; want %q, %q", test.sentence, got, spans, test.want, test.wantSpans)
+			}
+		})
+	}
+}
+
+func TestProtectedBraces(t *testing.T) {
+	tests := []struct {
+		sentence string
+		want     [][]int
+	}{
+		{"no braces", nil},
+		{"{a}", [][]int{{0, 3}}},
+		{"x ${a} y", [][]int{{2, 6}}},
+		{"{{a}}", [][]int{{0, 5}}},
+		{"{n, plural, one {{x}} other {y}}", [][]int{{0, 32}}},
+		{"{a} and {b}", [][]int{{0, 3}, {8, 11}}},
+		{"{not one}", nil},
+		{"{unclosed", nil},
+		{"}{a}", [][]int{{1, 4}}},
+	}
+	for _, test := range tests {
+		got := protectedBraces(test.sentence)
+		if !slices.EqualFunc(got, test.want, slices.Equal) {
+			t.Errorf("protectedBraces(%q) = %v, want %v", test.sentence, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+)
+
+// reembedBatchSize returns the number of entries re-embedded per call, read
+// from REEMBED_BATCH_SIZE
+func reembedBatchSize() int {
+	size, err := strconv.Atoi(getEnvWithDefault("REEMBED_BATCH_SIZE", "100"))
+	if err != nil || size <= 0 {
+		return 100
+	}
+	return size
+}
+
+// reembedCache re-embeds the source text of every cache entry that was not
+// embedded by the active model, so that it can be matched by similarity
+// again. Entries are processed in id order, so an interrupted run can simply
+// be restarted.
+func reembedCache(ctx context.Context, batchSize int) error {
+	// The active model is only reported alongside embeddings
+	_, model, err := getEmbeddings(ctx, []string{"model probe"})
+	if err != nil {
+		return fmt.Errorf("error getting embedding: %w", err)
+	}
+	log.Printf("Re-embedding cache entries with model %s", model)
+
+	var afterID, total int64
+	for {
+		entries, err := store.FindStaleEmbeddings(ctx, model, afterID, batchSize)
+		if err != nil {
+			return fmt.Errorf("error accessing cache: %w", err)
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		texts := make([]string, len(entries))
+		for i, entry := range entries {
+			texts[i] = entry.SourceText
+		}
+		embeddings, batchModel, err := getEmbeddings(ctx, texts)
+		if err != nil {
+			return fmt.Errorf("error getting embedding: %w", err)
+		}
+		if batchModel != model {
+			return fmt.Errorf("embedding model changed from %s to %s during re-embedding", model, batchModel)
+		}
+
+		for i, entry := range entries {
+			if err := store.UpdateEmbedding(ctx, entry.ID, model, embeddings[i]); err != nil {
+				return fmt.Errorf("error updating entry %d: %w", entry.ID, err)
+			}
+		}
+		afterID = entries[len(entries)-1].ID
+		total += int64(len(entries))
+		log.Printf("Re-embedded %d entries", total)
+	}
+
+	log.Printf("Re-embedding complete, %d entries updated", total)
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// Scoring policy weights; a candidate's score is its cosine distance plus
+// penalties minus its origin and priority bonuses, and the lowest scoring
+// candidate that passes verification wins
+const (
+	// lengthPenalty is added per unit of relative length difference between
+	// the sentence and the candidate's source text
+	lengthPenalty = 0.05
+	// patchPenalty is added when the candidate needed numbers substituted
+	patchPenalty = 0.02
+	// originBonus is subtracted for human-approved and imported candidates,
+	// so they win over machine entries that are slightly nearer
+	originBonus = 0.02
+	// priorityBonus is subtracted per point of the candidate's priority
+	priorityBonus = 0.02
+)
+
+// cacheCandidate describes how a cached entry was evaluated for a sentence,
+// returned to callers that request debug output
+type cacheCandidate struct {
+	Sentence   int     `json:"sentence"`
+	ID         int64   `json:"id"`
+	SourceText string  `json:"source_text"`
+	TargetText string  `json:"target_text"`
+	Distance   float64 `json:"distance"`
+	Origin     string  `json:"source"`
+	Priority   int     `json:"priority"`
+	Score      float64 `json:"score"`
+	Outcome    string  `json:"outcome"`
+	Selected   bool    `json:"selected"`
+}
+
+// cacheCandidateLimit returns how many nearest cached entries are considered
+// for each sentence
+func cacheCandidateLimit() int {
+	limit, err := strconv.Atoi(getEnvWithDefault("CACHE_CANDIDATES", "5"))
+	if err != nil || limit <= 0 {
+		return 5
+	}
+	return limit
+}
+
+// selectCacheHit verifies and scores the nearest cached entries for a
+// sentence and returns the translation of the best one, if any passed, along
+// with the evaluation of every candidate
+func selectCacheHit(sourceLang string, sentence int, text string, entries []cacheEntry) (string, *cacheCandidate, []cacheCandidate) {
+	candidates := make([]cacheCandidate, len(entries))
+	translations := make([]string, len(entries))
+	best := -1
+	for i, entry := range entries {
+		translation, outcome := verifyCacheHit(sourceLang, text, entry.SourceText, entry.TargetText)
+		candidates[i] = cacheCandidate{
+			Sentence:   sentence,
+			ID:         entry.ID,
+			SourceText: entry.SourceText,
+			TargetText: entry.TargetText,
+			Distance:   entry.Distance,
+			Origin:     entry.Origin,
+			Priority:   entry.Priority,
+			Score:      scoreCandidate(text, entry, outcome),
+			Outcome:    outcome,
+		}
+		translations[i] = translation
+		if guardPassed(outcome) && (best < 0 || candidates[i].Score < candidates[best].Score) {
+			best = i
+		}
+	}
+
+	if best < 0 {
+		return "", nil, candidates
+	}
+	candidates[best].Selected = true
+	return translations[best], &candidates[best], candidates
+}
+
+// scoreCandidate scores a cached entry for a sentence; lower is better
+func scoreCandidate(text string, entry cacheEntry, outcome string) float64 {
+	score := entry.Distance
+
+	textLen, sourceLen := utf8.RuneCountInString(text), utf8.RuneCountInString(entry.SourceText)
+	if longest := max(textLen, sourceLen); longest > 0 {
+		score += lengthPenalty * float64(abs(textLen-sourceLen)) / float64(longest)
+	}
+	if outcome == guardPatched {
+		score += patchPenalty
+	}
+	if entry.Origin != originMachine {
+		score -= originBonus
+	}
+	return score - priorityBonus*float64(entry.Priority)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
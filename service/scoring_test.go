@@ -0,0 +1,81 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScoreCandidate(t *testing.T) {
+	text := "Save the file now."
+	tests := []struct {
+		name    string
+		entry   cacheEntry
+		outcome string
+		want    float64
+	}{
+		{"distance only", cacheEntry{SourceText: "Save the file now!", Distance: 0.04, Origin: originMachine}, guardAccepted, 0.04},
+		{"length difference", cacheEntry{SourceText: "Save the file.", Distance: 0.04, Origin: originMachine}, guardAccepted, 0.04 + lengthPenalty*4/18},
+		{"patched", cacheEntry{SourceText: "Save the file now!", Distance: 0.04, Origin: originMachine}, guardPatched, 0.04 + patchPenalty},
+		{"human", cacheEntry{SourceText: "Save the file now!", Distance: 0.04, Origin: originHuman}, guardAccepted, 0.04 - originBonus},
+		{"imported with priority", cacheEntry{SourceText: "Save the file now!", Distance: 0.04, Origin: originImported, Priority: 2}, guardAccepted, 0.04 - originBonus - 2*priorityBonus},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := scoreCandidate(text, test.entry, test.outcome); math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("scoreCandidate() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSelectCacheHit(t *testing.T) {
+	text := "Your order ships in 5 days."
+	tests := []struct {
+		name    string
+		entries []cacheEntry
+		want    string
+		wantID  int64
+	}{
+		{"nearest", []cacheEntry{
+			{ID: 1, SourceText: "Your order ships in 5 days!", TargetText: "A", Distance: 0.02, Origin: originMachine},
+			{ID: 2, SourceText: "Your order ships in 5 days?", TargetText: "B", Distance: 0.05, Origin: originMachine},
+		}, "A", 1},
+		{"rejected nearest", []cacheEntry{
+			{ID: 1, SourceText: "Your order never ships in 5 days.", TargetText: "A", Distance: 0.02, Origin: originMachine},
+			{ID: 2, SourceText: "Your order ships in 5 days?", TargetText: "B", Distance: 0.05, Origin: originMachine},
+		}, "B", 2},
+		{"unpatched over patched", []cacheEntry{
+			{ID: 1, SourceText: "Your order ships in 3 days.", TargetText: "Llega en 3 días.", Distance: 0.03, Origin: originMachine},
+			{ID: 2, SourceText: "Your order ships in 5 days!", TargetText: "Llega en 5 días.", Distance: 0.04, Origin: originMachine},
+		}, "Llega en 5 días.", 2},
+		{"patched when alone", []cacheEntry{
+			{ID: 1, SourceText: "Your order ships in 3 days.", TargetText: "Llega en 3 días.", Distance: 0.03, Origin: originMachine},
+		}, "Llega en 5 días.", 1},
+		{"human over nearer machine", []cacheEntry{
+			{ID: 1, SourceText: "Your order ships in 5 days!", TargetText: "A", Distance: 0.02, Origin: originMachine},
+			{ID: 2, SourceText: "Your order ships in 5 days?", TargetText: "B", Distance: 0.03, Origin: originHuman},
+		}, "B", 2},
+		{"priority", []cacheEntry{
+			{ID: 1, SourceText: "Your order ships in 5 days!", TargetText: "A", Distance: 0.02, Origin: originHuman},
+			{ID: 2, SourceText: "Your order ships in 5 days?", TargetText: "B", Distance: 0.04, Origin: originHuman, Priority: 2},
+		}, "B", 2},
+		{"all rejected", []cacheEntry{
+			{ID: 1, SourceText: "Your order ships in 5 weeks, not days.", TargetText: "A", Distance: 0.02, Origin: originMachine},
+		}, "", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, selected, candidates := selectCacheHit("en", 0, text, test.entries)
+			if len(candidates) != len(test.entries) {
+				t.Fatalf("got %d candidates, want %d", len(candidates), len(test.entries))
+			}
+			var id int64
+			if selected != nil {
+				id = selected.ID
+			}
+			if got != test.want || id != test.wantID {
+				t.Errorf("selectCacheHit() = %q from %d, want %q from %d", got, id, test.want, test.wantID)
+			}
+		})
+	}
+}
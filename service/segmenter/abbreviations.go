@@ -0,0 +1,37 @@
+package segmenter
+
+// abbreviations lists, per language, the lowercase words that do not end a
+// sentence when followed by a period
+var abbreviations = map[string]map[string]bool{
+	"en": set(
+		"mr", "mrs", "ms", "dr", "prof", "sr", "jr", "st", "mt", "vs", "etc",
+		"e.g", "i.e", "inc", "ltd", "co", "corp", "dept", "approx",
+		"vol", "fig", "p", "pp", "ch", "sec", "gov", "rev",
+		"jan", "feb", "mar", "apr", "jun", "jul", "aug", "sep", "sept", "oct", "nov", "dec",
+		"a.m", "p.m", "u.s", "u.k",
+	),
+	"es": set(
+		"sr", "sra", "srta", "dr", "dra", "lic", "ing", "prof", "ud", "uds", "vd", "vds",
+		"etc", "p.ej", "pág", "págs", "núm", "nº", "cap", "av", "avda", "admón", "aprox",
+		"ene", "feb", "mar", "abr", "jun", "jul", "ago", "sept", "oct", "nov", "dic",
+	),
+	"fr": set(
+		"m", "mm", "mme", "mmes", "mlle", "mlles", "dr", "pr", "me", "st", "ste",
+		"etc", "ex", "p.ex", "cf", "env", "av", "bd", "n°", "p", "vol", "chap",
+		"janv", "févr", "avr", "juil", "sept", "oct", "nov", "déc",
+	),
+	"de": set(
+		"dr", "prof", "hr", "fr", "nr", "str", "ca", "bzw", "usw", "etc", "evtl",
+		"ggf", "vgl", "z.b", "d.h", "u.a", "s.o", "s.u", "u.u", "inkl", "exkl",
+		"bspw", "abs", "abschn", "bd", "jh", "mio", "mrd", "tel",
+		"jan", "feb", "apr", "aug", "sept", "okt", "nov", "dez",
+	),
+}
+
+func set(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
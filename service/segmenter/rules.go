@@ -0,0 +1,144 @@
+package segmenter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuleSegmenter splits text at sentence terminators following a subset of
+// the Unicode sentence boundary rules (UAX #29), skipping known abbreviations
+type RuleSegmenter struct {
+	// Abbreviations are lowercase words, without their final period, that do
+	// not end a sentence when followed by a period (e.g. "dr", "e.g")
+	Abbreviations map[string]bool
+}
+
+// Segment splits text into sentences; line breaks always end a sentence
+func (s *RuleSegmenter) Segment(text string) []Segment {
+	var segments []Segment
+
+	start := len(text) - len(strings.TrimLeftFunc(text, unicode.IsSpace))
+	leading := text[:start]
+
+	// emit records the sentence text[start:end] and the whitespace following it
+	emit := func(end int) {
+		sentence := strings.TrimRightFunc(text[start:end], unicode.IsSpace)
+		next := end + len(text[end:]) - len(strings.TrimLeftFunc(text[end:], unicode.IsSpace))
+		if sentence != "" {
+			segment := Segment{Text: sentence, Trailing: text[start+len(sentence) : next]}
+			if len(segments) == 0 {
+				segment.Leading = leading
+			}
+			segments = append(segments, segment)
+		}
+		start = next
+	}
+
+	for pos := start; pos < len(text); {
+		r, size := utf8.DecodeRuneInString(text[pos:])
+		switch {
+		case r == '\n':
+			emit(pos)
+			pos = start
+		case isTerminator(r):
+			end := pos + size
+			for end < len(text) {
+				next, nextSize := utf8.DecodeRuneInString(text[end:])
+				if !isTerminator(next) && !isCloser(next) {
+					break
+				}
+				end += nextSize
+			}
+			if s.isBoundary(text, start, pos, end, r) {
+				emit(end)
+				pos = start
+			} else {
+				pos = end
+			}
+		default:
+			pos += size
+		}
+	}
+	if start < len(text) {
+		emit(len(text))
+	}
+	return segments
+}
+
+// isBoundary reports whether the terminator run text[pos:end], starting with
+// terminator r, ends the sentence that began at start
+func (s *RuleSegmenter) isBoundary(text string, start, pos, end int, r rune) bool {
+	if isFullWidthTerminator(r) || end == len(text) {
+		return true
+	}
+
+	// Latin-style terminators must be followed by whitespace ("3.14", "U.S.A")
+	next, _ := utf8.DecodeRuneInString(text[end:])
+	if !unicode.IsSpace(next) {
+		return false
+	}
+	rest := strings.TrimLeftFunc(text[end:], unicode.IsSpace)
+	if strings.Contains(text[end:len(text)-len(rest)], "\n") {
+		return true
+	}
+	if r != '.' && r != '…' {
+		return true
+	}
+
+	// A period or ellipsis followed by a lowercase word continues the sentence
+	if following, _ := utf8.DecodeRuneInString(rest); unicode.IsLower(following) {
+		return false
+	}
+	if end != pos+1 {
+		return true
+	}
+
+	word := text[start:pos]
+	if i := strings.LastIndexFunc(word, unicode.IsSpace); i >= 0 {
+		word = word[i+1:]
+	}
+	word = strings.TrimLeftFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+
+	// Single letter initials as in "J. R. R. Tolkien"
+	if first, size := utf8.DecodeRuneInString(word); size == len(word) && unicode.IsUpper(first) {
+		return false
+	}
+	return !s.Abbreviations[strings.ToLower(word)]
+}
+
+// isTerminator reports whether r ends a sentence
+func isTerminator(r rune) bool {
+	switch r {
+	case '.', '!', '?', '…', '‼', '⁇', '⁈', '⁉', '؟', '۔', '।', '॥':
+		return true
+	}
+	return isFullWidthTerminator(r)
+}
+
+// isFullWidthTerminator reports whether r is a CJK sentence terminator, which
+// ends a sentence without needing following whitespace
+func isFullWidthTerminator(r rune) bool {
+	switch r {
+	case '。', '！', '？', '．', '｡':
+		return true
+	}
+	return false
+}
+
+// isCloser reports whether r is closing punctuation that belongs to the
+// preceding sentence when it follows a terminator
+func isCloser(r rune) bool {
+	switch r {
+	case '"', '\'', ')', ']', '}', '»', '”', '’', '」', '』', '）', '〕', '】', '》', '〉':
+		return true
+	}
+	return false
+}
+
+// isCJK reports whether r belongs to a script written without spaces
+// between sentences
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) ||
+		(r >= 0x3000 && r <= 0x303f) || (r >= 0xff00 && r <= 0xffef)
+}
@@ -0,0 +1,90 @@
+// Package segmenter splits text into sentences for translation while
+// recording the separators between them, so that translated sentences can be
+// reassembled with the original whitespace and paragraph layout.
+package segmenter
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Segment is a single sentence together with the whitespace surrounding it in
+// the original text
+type Segment struct {
+	Leading  string // Whitespace preceding the sentence, only set on the first segment
+	Text     string // The sentence, including its terminating punctuation
+	Trailing string // Whitespace following the sentence up to the next one
+}
+
+// Segmenter splits text into sentences
+type Segmenter interface {
+	Segment(text string) []Segment
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Segmenter{}
+)
+
+// Register installs a segmenter for a language code, replacing the built-in
+// rules for that language
+func Register(lang string, s Segmenter) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[BaseLanguage(lang)] = s
+}
+
+// For returns the segmenter for a language code, falling back to the
+// rule-based segmenter with that language's abbreviation list
+func For(lang string) Segmenter {
+	lang = BaseLanguage(lang)
+
+	registryMu.RLock()
+	s, ok := registry[lang]
+	registryMu.RUnlock()
+	if ok {
+		return s
+	}
+	return &RuleSegmenter{Abbreviations: abbreviations[lang]}
+}
+
+// Join reassembles translated sentences using the separators recorded in
+// segments; translations must be in the same order as segments
+func Join(segments []Segment, translations []string) string {
+	var b strings.Builder
+	for i, segment := range segments {
+		b.WriteString(segment.Leading)
+		b.WriteString(translations[i])
+		if i == len(segments)-1 {
+			b.WriteString(segment.Trailing)
+			break
+		}
+		b.WriteString(separator(segment.Trailing, translations[i], translations[i+1]))
+	}
+	return b.String()
+}
+
+// separator adapts a recorded separator to the scripts on either side of it:
+// CJK text is not space separated, while most other scripts are
+func separator(sep, prev, next string) string {
+	last, _ := utf8.DecodeLastRuneInString(prev)
+	first, _ := utf8.DecodeRuneInString(next)
+	switch {
+	case sep == "" && !isCJK(last) && !unicode.IsSpace(last) && !isCJK(first):
+		return " "
+	case sep != "" && strings.TrimLeft(sep, " \t　") == "" && isCJK(last) && isCJK(first):
+		return ""
+	}
+	return sep
+}
+
+// BaseLanguage reduces a language tag such as "en-US" to its primary subtag
+func BaseLanguage(lang string) string {
+	lang = strings.ToLower(lang)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
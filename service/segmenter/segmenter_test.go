@@ -0,0 +1,101 @@
+package segmenter
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSegment(t *testing.T) {
+	tests := []struct {
+		name string
+		lang string
+		text string
+		want []string
+	}{
+		{"single sentence", "en", "Hello world", []string{"Hello world"}},
+		{"terminators", "en", "Hello world. How are you? Fine!", []string{"Hello world.", "How are you?", "Fine!"}},
+		{"abbreviation", "en", "Dr. Smith arrived. He sat down.", []string{"Dr. Smith arrived.", "He sat down."}},
+		{"abbreviation with inner period", "en", "Bring fruit, e.g. Apples. Then leave.", []string{"Bring fruit, e.g. Apples.", "Then leave."}},
+		{"abbreviation of another language", "en", "Ca. 20 people came.", []string{"Ca.", "20 people came."}},
+		{"german abbreviation", "de-DE", "Das kostet ca. 20 Euro. Danke.", []string{"Das kostet ca. 20 Euro.", "Danke."}},
+		{"spanish abbreviation", "es", "La Sra. García llegó. Se sentó.", []string{"La Sra. García llegó.", "Se sentó."}},
+		{"initials", "en", "J. R. R. Tolkien wrote it. It sold well.", []string{"J. R. R. Tolkien wrote it.", "It sold well."}},
+		{"lowercase continuation", "en", "It costs approx. ten dollars... or so. Fine.", []string{"It costs approx. ten dollars... or so.", "Fine."}},
+		{"numbers and acronyms", "en", "Pi is 3.14 in the U.S.A today.", []string{"Pi is 3.14 in the U.S.A today."}},
+		{"terminator run", "en", "Really?! Yes.", []string{"Really?!", "Yes."}},
+		{"closing quote", "en", `He said "Stop." Then he left.`, []string{`He said "Stop."`, "Then he left."}},
+		{"closing parenthesis", "en", "See the notes (in red.) They help.", []string{"See the notes (in red.)", "They help."}},
+		{"line breaks", "en", "Title\nFirst line.\n\nSecond paragraph", []string{"Title", "First line.", "Second paragraph"}},
+		{"cjk", "zh", "你好。今天天气很好！你呢？", []string{"你好。", "今天天气很好！", "你呢？"}},
+		{"cjk closer", "ja", "「こんにちは。」と言った。", []string{"「こんにちは。」", "と言った。"}},
+		{"arabic question mark", "ar", "كيف حالك؟ أنا بخير.", []string{"كيف حالك؟", "أنا بخير."}},
+		{"blank", "en", " \n\t", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, segment := range For(test.lang).Segment(test.text) {
+				got = append(got, segment.Text)
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("Segment(%q) = %q, want %q", test.text, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSegmentWhitespace(t *testing.T) {
+	text := "  First.  Second.\n\nThird. "
+	want := []Segment{
+		{Leading: "  ", Text: "First.", Trailing: "  "},
+		{Text: "Second.", Trailing: "\n\n"},
+		{Text: "Third.", Trailing: " "},
+	}
+	if got := For("en").Segment(text); !slices.Equal(got, want) {
+		t.Errorf("Segment(%q) = %q, want %q", text, got, want)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		translations []string
+		want         string
+	}{
+		{"keeps layout", "  One.  Two.\n\nThree. ", []string{"Uno.", "Dos.", "Tres."}, "  Uno.  Dos.\n\nTres. "},
+		{"latin after cjk", "你好。再见。", []string{"Hello.", "Goodbye."}, "Hello. Goodbye."},
+		{"cjk after latin", "Hello. Goodbye.", []string{"你好。", "再见。"}, "你好。再见。"},
+		{"keeps line breaks between cjk", "Hello.\nGoodbye.", []string{"你好。", "再见。"}, "你好。\n再见。"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			segments := For("").Segment(test.text)
+			if got := Join(segments, test.translations); got != test.want {
+				t.Errorf("Join(%q) = %q, want %q", test.translations, got, test.want)
+			}
+		})
+	}
+}
+
+type lineSegmenter struct{}
+
+func (lineSegmenter) Segment(text string) []Segment {
+	return []Segment{{Text: text}}
+}
+
+func TestRegister(t *testing.T) {
+	Register("xx-YY", lineSegmenter{})
+	defer func() {
+		registryMu.Lock()
+		delete(registry, "xx")
+		registryMu.Unlock()
+	}()
+
+	if _, ok := For("XX_zz").(lineSegmenter); !ok {
+		t.Errorf("For(%q) did not return the registered segmenter", "XX_zz")
+	}
+	if _, ok := For("en").(*RuleSegmenter); !ok {
+		t.Errorf("For(%q) did not return the rule segmenter", "en")
+	}
+}
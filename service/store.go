@@ -0,0 +1,836 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pgvector/pgvector-go"
+	pgxvec "github.com/pgvector/pgvector-go/pgx"
+)
+
+// Store persists cached translations; implementations must be safe for
+// concurrent use
+type Store interface {
+	// FindExact retrieves a cached translation made with the glossary whose
+	// normalized source text hash matches exactly, recording the hit
+	FindExact(ctx context.Context, sourceLang, targetLang string, glossaryID int64, hash string) (cacheEntry, bool, error)
+	// FindNearest retrieves up to limit cached translations made with the
+	// glossary and embedded by model, with the dimension of embedding, within
+	// the given cosine distance, nearest first, along with up to limit
+	// human-approved and imported ones that are further away
+	FindNearest(ctx context.Context, sourceLang, targetLang string, glossaryID int64, model string, embedding []float32, threshold float64, limit int) ([]cacheEntry, error)
+	// Save stores a translation and returns its id, ignoring it and returning
+	// 0 if the exact source is already cached, so that it never overwrites
+	// human-approved entries
+	Save(ctx context.Context, record cacheRecord) (int64, error)
+	// SaveOverride stores a translation and returns its id, replacing the
+	// entry of the same source text if any
+	SaveOverride(ctx context.Context, record cacheRecord) (int64, error)
+	// FindStaleEmbeddings retrieves up to limit entries with an id above
+	// afterID that were not embedded by model, in id order
+	FindStaleEmbeddings(ctx context.Context, model string, afterID int64, limit int) ([]cacheEntry, error)
+	// UpdateEmbedding replaces an entry's embedding and its model
+	UpdateEmbedding(ctx context.Context, id int64, model string, embedding []float32) error
+	// RecordHit updates an entry's last hit time and hit count
+	RecordHit(ctx context.Context, id int64) error
+	// PairCounts returns the number of machine entries of each language
+	// pair, the only ones that are evicted
+	PairCounts(ctx context.Context) ([]pairCount, error)
+	// DeleteExpired deletes up to limit machine entries of a pair created
+	// before the given time and returns how many were deleted
+	DeleteExpired(ctx context.Context, sourceLang, targetLang string, before time.Time, limit int) (int64, error)
+	// DeleteLeastUsed deletes up to limit machine entries of a pair, least
+	// recently used first for evictLRU or least frequently used first for
+	// evictLFU, and returns how many were deleted
+	DeleteLeastUsed(ctx context.Context, sourceLang, targetLang, policy string, limit int) (int64, error)
+	// ListRows retrieves up to limit entries matching filter in id order,
+	// skipping the first offset
+	ListRows(ctx context.Context, filter cacheFilter, limit, offset int) ([]cacheRow, error)
+	// GetRow retrieves an entry by id
+	GetRow(ctx context.Context, id int64) (cacheRow, bool, error)
+	// UpdateTargetText replaces an entry's translation and marks it human
+	// approved, reporting whether it exists
+	UpdateTargetText(ctx context.Context, id int64, targetText string) (bool, error)
+	// DeleteRow deletes an entry by id, reporting whether it existed
+	DeleteRow(ctx context.Context, id int64) (bool, error)
+	// DeleteRows deletes the entries matching filter and returns how many
+	// were deleted
+	DeleteRows(ctx context.Context, filter cacheFilter) (int64, error)
+	// PairStats returns usage statistics for each language pair
+	PairStats(ctx context.Context) ([]pairStats, error)
+	// FindGlossary retrieves the glossary of a tenant for a language pair,
+	// falling back to the default glossary with an empty tenant
+	FindGlossary(ctx context.Context, tenant, sourceLang, targetLang string) (glossary, bool, error)
+	// ListGlossaries retrieves every glossary without its terms
+	ListGlossaries(ctx context.Context) ([]glossary, error)
+	// GetGlossary retrieves a glossary with its terms
+	GetGlossary(ctx context.Context, id int64) (glossary, bool, error)
+	// CreateGlossary stores a glossary with its terms and returns its id, or
+	// errGlossaryExists if the tenant already has one for the pair
+	CreateGlossary(ctx context.Context, g glossary) (int64, error)
+	// ReplaceGlossaryTerms replaces a glossary's terms, reporting whether it
+	// exists. If the source terms changed, it returns the ids of the
+	// human-approved and imported entries cached with the glossary, whose
+	// masked source text may no longer match any sentence.
+	ReplaceGlossaryTerms(ctx context.Context, id int64, terms []glossaryTerm) ([]int64, bool, error)
+	// DeleteGlossary deletes a glossary and the machine entries cached with
+	// it, reporting whether it existed, and returns the ids of the
+	// human-approved and imported entries cached with it, which are kept
+	DeleteGlossary(ctx context.Context, id int64) ([]int64, bool, error)
+	// Close releases the store's resources
+	Close()
+}
+
+// cacheEntry is a cached translation; Distance, Origin and Priority are only
+// set for similarity matches
+type cacheEntry struct {
+	ID         int64
+	SourceText string
+	TargetText string
+	Distance   float64
+	Origin     string
+	Priority   int
+}
+
+// Origins of a cached translation, stored in the source column
+const (
+	originMachine  = "machine"  // Translated by a backend
+	originHuman    = "human"    // Corrected by a translator
+	originImported = "imported" // Loaded from an existing translation memory
+)
+
+// cacheRow is a cache entry with its metadata, as shown by the admin API
+type cacheRow struct {
+	ID             int64      `json:"id"`
+	SourceLanguage string     `json:"source_language"`
+	TargetLanguage string     `json:"target_language"`
+	SourceText     string     `json:"source_text"`
+	TargetText     string     `json:"target_text"`
+	GlossaryID     int64      `json:"glossary_id"`
+	EmbeddingModel string     `json:"embedding_model"`
+	Origin         string     `json:"source"`
+	Priority       int        `json:"priority"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastHitAt      *time.Time `json:"last_hit_at"`
+	HitCount       int64      `json:"hit_count"`
+}
+
+// cacheFilter selects cache entries; empty fields match everything
+type cacheFilter struct {
+	SourceLanguage string
+	TargetLanguage string
+	Query          string // Case-insensitive substring of the source text
+	Pattern        string // SQL LIKE pattern matched against the source text
+}
+
+// pairStats summarizes the cache entries of a language pair
+type pairStats struct {
+	SourceLanguage string     `json:"source_language"`
+	TargetLanguage string     `json:"target_language"`
+	Entries        int64      `json:"entries"`
+	HitEntries     int64      `json:"hit_entries"`
+	Hits           int64      `json:"hits"`
+	OldestAt       time.Time  `json:"oldest_at"`
+	NewestAt       time.Time  `json:"newest_at"`
+	LastHitAt      *time.Time `json:"last_hit_at"`
+}
+
+// pairCount is the number of machine entries of a language pair
+type pairCount struct {
+	SourceLanguage string
+	TargetLanguage string
+	Rows           int64
+}
+
+// cacheRecord is a translation to be cached
+type cacheRecord struct {
+	SourceLanguage string
+	TargetLanguage string
+	SourceText     string
+	TargetText     string
+	SourceHash     string
+	Embedding      []float32
+	EmbeddingModel string
+	Origin         string // originMachine, originHuman or originImported
+	Priority       int
+	GlossaryID     int64 // The glossary the source text was masked with, 0 for none
+}
+
+// glossary holds the mandated translations of terms for a tenant and
+// language pair; the empty tenant is the default for every tenant
+type glossary struct {
+	ID             int64          `json:"id"`
+	Tenant         string         `json:"tenant"`
+	SourceLanguage string         `json:"source_language"`
+	TargetLanguage string         `json:"target_language"`
+	Terms          []glossaryTerm `json:"terms,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// glossaryTerm is a source term and the target term it must be translated to
+type glossaryTerm struct {
+	SourceTerm string `json:"source_term"`
+	TargetTerm string `json:"target_term"`
+	IgnoreCase bool   `json:"ignore_case"`
+}
+
+// errGlossaryExists is returned when creating a second glossary for a tenant
+// and language pair
+var errGlossaryExists = errors.New("glossary already exists")
+
+// Prepared statement names
+const (
+	stmtFindExact           = "find_exact"
+	stmtSave                = "save"
+	stmtSaveOverride        = "save_override"
+	stmtFindStaleEmbeddings = "find_stale_embeddings"
+	stmtUpdateEmbedding     = "update_embedding"
+	stmtRecordHit           = "record_hit"
+	stmtPairCounts          = "pair_counts"
+	stmtDeleteExpired       = "delete_expired"
+	stmtDeleteLRU           = "delete_lru"
+	stmtDeleteLFU           = "delete_lfu"
+	stmtListRows            = "list_rows"
+	stmtGetRow              = "get_row"
+	stmtUpdateTargetText    = "update_target_text"
+	stmtDeleteRow           = "delete_row"
+	stmtDeleteRows          = "delete_rows"
+	stmtPairStats           = "pair_stats"
+	stmtFindGlossary        = "find_glossary"
+	stmtListGlossaries      = "list_glossaries"
+	stmtGetGlossary         = "get_glossary"
+	stmtGlossaryTerms       = "glossary_terms"
+	stmtCreateGlossary      = "create_glossary"
+	stmtTouchGlossary       = "touch_glossary"
+	stmtDeleteGlossaryTerms = "delete_glossary_terms"
+	stmtInsertGlossaryTerms = "insert_glossary_terms"
+	stmtDeleteGlossary      = "delete_glossary"
+	stmtDeleteGlossaryCache = "delete_glossary_cache"
+	stmtPinnedCorrections   = "pinned_corrections"
+)
+
+// cacheRowColumns are the columns scanned by scanCacheRow
+const cacheRowColumns = `id, source_language, target_language, source_text, target_text, glossary_id, embedding_model, source, priority, created_at, last_hit_at, hit_count`
+
+// cacheFilterCondition matches the cacheFilter fields passed as $1 to $4
+const cacheFilterCondition = `
+            ($1 = '' OR source_language = $1)
+            AND ($2 = '' OR target_language = $2)
+            AND ($3 = '' OR source_text ILIKE '%' || $3 || '%')
+            AND ($4 = '' OR source_text LIKE $4)`
+
+// glossaryColumns are the columns scanned by scanGlossary
+const glossaryColumns = `id, tenant, source_language, target_language, created_at, updated_at`
+
+// glossaryCondition matches the entries cached with the glossary passed as
+// $4, and the human-approved and imported entries cached without a glossary
+const glossaryCondition = `(glossary_id = $4 OR (glossary_id = 0 AND source <> 'machine'))`
+
+// findNearestQuery returns the similarity search among the entries embedded
+// with the given number of dimensions. The dimension is written into the SQL
+// so that the query can use the partial index of that dimension, and the
+// threshold is applied outside the nearest rows so that distances are only
+// computed for rows of that dimension, as comparing vectors of different
+// dimensions fails. The nearest human-approved and imported entries are
+// searched separately so that nearer machine entries do not crowd them out.
+func findNearestQuery(dimensions int) string {
+	dims := strconv.Itoa(dimensions)
+	vector := "embedding::vector(" + dims + ")"
+	nearest := func(condition string) string {
+		return `
+            SELECT id, source_text, target_text, ` + vector + ` <=> $3 AS distance, source, priority
+            FROM translations_cache
+            WHERE source_language = $1
+            AND target_language = $2
+            AND ` + glossaryCondition + `
+            AND embedding_model = $7
+            AND embedding_dimensions = ` + dims + condition + `
+            ORDER BY ` + vector + ` <=> $3
+            LIMIT $6`
+	}
+	return `
+        SELECT id, source_text, target_text, distance, source, priority
+        FROM ((` + nearest("") + `
+        ) UNION (` + nearest(`
+            AND source <> 'machine'`) + `
+        )) nearest
+        WHERE distance <= $5
+        ORDER BY distance;
+    `
+}
+
+// statements are prepared on every pooled connection
+var statements = map[string]string{
+	stmtFindExact: `
+        UPDATE translations_cache
+        SET last_hit_at = now(), hit_count = hit_count + 1
+        WHERE id = (
+            SELECT id FROM translations_cache
+            WHERE source_language = $1
+            AND target_language = $2
+            AND source_hash = $3
+            AND ` + glossaryCondition + `
+            ORDER BY source <> 'machine' DESC, priority DESC
+            LIMIT 1
+        )
+        RETURNING id, source_text, target_text;
+    `,
+	stmtSave: `
+        INSERT INTO translations_cache (source_language, target_language, embedding, target_text, source_text, source_hash, embedding_model, embedding_dimensions, source, priority, glossary_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        ON CONFLICT (source_language, target_language, glossary_id, source_hash) DO NOTHING
+        RETURNING id;
+    `,
+	stmtSaveOverride: `
+        INSERT INTO translations_cache (source_language, target_language, embedding, target_text, source_text, source_hash, embedding_model, embedding_dimensions, source, priority, glossary_id)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        ON CONFLICT (source_language, target_language, glossary_id, source_hash) DO UPDATE
+        SET embedding = EXCLUDED.embedding, target_text = EXCLUDED.target_text, source_text = EXCLUDED.source_text,
+            embedding_model = EXCLUDED.embedding_model, embedding_dimensions = EXCLUDED.embedding_dimensions,
+            source = EXCLUDED.source, priority = EXCLUDED.priority
+        RETURNING id;
+    `,
+	stmtFindStaleEmbeddings: `
+        SELECT id, source_text, target_text
+        FROM translations_cache
+        WHERE id > $2
+        AND (embedding_model <> $1 OR embedding IS NULL)
+        ORDER BY id
+        LIMIT $3;
+    `,
+	stmtUpdateEmbedding: `
+        UPDATE translations_cache
+        SET embedding = $3, embedding_model = $2, embedding_dimensions = $4
+        WHERE id = $1;
+    `,
+	stmtRecordHit: `
+        UPDATE translations_cache
+        SET last_hit_at = now(), hit_count = hit_count + 1
+        WHERE id = $1;
+    `,
+	stmtPairCounts: `
+        SELECT source_language, target_language, count(*)
+        FROM translations_cache
+        WHERE source = 'machine'
+        GROUP BY source_language, target_language;
+    `,
+	stmtDeleteExpired: `
+        DELETE FROM translations_cache
+        WHERE id IN (
+            SELECT id FROM translations_cache
+            WHERE source_language = $1
+            AND target_language = $2
+            AND source = 'machine'
+            AND created_at < $3
+            LIMIT $4
+        );
+    `,
+	stmtDeleteLRU: `
+        DELETE FROM translations_cache
+        WHERE id IN (
+            SELECT id FROM translations_cache
+            WHERE source_language = $1
+            AND target_language = $2
+            AND source = 'machine'
+            ORDER BY coalesce(last_hit_at, created_at)
+            LIMIT $3
+        );
+    `,
+	stmtDeleteLFU: `
+        DELETE FROM translations_cache
+        WHERE id IN (
+            SELECT id FROM translations_cache
+            WHERE source_language = $1
+            AND target_language = $2
+            AND source = 'machine'
+            ORDER BY hit_count, coalesce(last_hit_at, created_at)
+            LIMIT $3
+        );
+    `,
+	stmtListRows: `
+        SELECT ` + cacheRowColumns + `
+        FROM translations_cache
+        WHERE` + cacheFilterCondition + `
+        ORDER BY id
+        LIMIT $5 OFFSET $6;
+    `,
+	stmtGetRow: `
+        SELECT ` + cacheRowColumns + `
+        FROM translations_cache
+        WHERE id = $1;
+    `,
+	stmtUpdateTargetText: `
+        UPDATE translations_cache
+        SET target_text = $2, source = 'human', priority = greatest(priority, 1)
+        WHERE id = $1;
+    `,
+	stmtDeleteRow: `
+        DELETE FROM translations_cache
+        WHERE id = $1;
+    `,
+	stmtDeleteRows: `
+        DELETE FROM translations_cache
+        WHERE` + cacheFilterCondition + `;
+    `,
+	stmtPairStats: `
+        SELECT source_language, target_language, count(*), count(*) FILTER (WHERE hit_count > 0),
+            coalesce(sum(hit_count), 0), min(created_at), max(created_at), max(last_hit_at)
+        FROM translations_cache
+        GROUP BY source_language, target_language
+        ORDER BY source_language, target_language;
+    `,
+	stmtFindGlossary: `
+        SELECT ` + glossaryColumns + `
+        FROM glossaries
+        WHERE tenant IN ($1, '')
+        AND source_language = $2
+        AND target_language = $3
+        ORDER BY tenant = $1 DESC
+        LIMIT 1;
+    `,
+	stmtListGlossaries: `
+        SELECT ` + glossaryColumns + `
+        FROM glossaries
+        ORDER BY id;
+    `,
+	stmtGetGlossary: `
+        SELECT ` + glossaryColumns + `
+        FROM glossaries
+        WHERE id = $1;
+    `,
+	stmtGlossaryTerms: `
+        SELECT source_term, target_term, ignore_case
+        FROM glossary_terms
+        WHERE glossary_id = $1
+        ORDER BY source_term;
+    `,
+	stmtCreateGlossary: `
+        INSERT INTO glossaries (tenant, source_language, target_language)
+        VALUES ($1, $2, $3)
+        RETURNING id;
+    `,
+	stmtTouchGlossary: `
+        UPDATE glossaries
+        SET updated_at = now()
+        WHERE id = $1;
+    `,
+	stmtDeleteGlossaryTerms: `
+        DELETE FROM glossary_terms
+        WHERE glossary_id = $1;
+    `,
+	stmtInsertGlossaryTerms: `
+        INSERT INTO glossary_terms (glossary_id, source_term, target_term, ignore_case)
+        SELECT $1, * FROM unnest($2::text[], $3::text[], $4::boolean[]);
+    `,
+	stmtDeleteGlossary: `
+        DELETE FROM glossaries
+        WHERE id = $1;
+    `,
+	stmtDeleteGlossaryCache: `
+        DELETE FROM translations_cache
+        WHERE glossary_id = $1
+        AND source = 'machine';
+    `,
+	stmtPinnedCorrections: `
+        SELECT id FROM translations_cache
+        WHERE glossary_id = $1
+        AND source <> 'machine'
+        ORDER BY id;
+    `,
+}
+
+// pgStore is a Store backed by a PostgreSQL connection pool
+type pgStore struct {
+	pool *pgxpool.Pool
+}
+
+// newPgStore connects a pool to the database; the pool is sized from
+// DB_MAX_CONNS and DB_MIN_CONNS when set, otherwise from the connection string
+func newPgStore(ctx context.Context, connStr string) (*pgStore, error) {
+	config, err := pgxpool.ParseConfig(connStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database URL: %w", err)
+	}
+	if value := getEnvWithDefault("DB_MAX_CONNS", ""); value != "" {
+		maxConns, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || maxConns <= 0 {
+			return nil, fmt.Errorf("invalid DB_MAX_CONNS %q", value)
+		}
+		config.MaxConns = int32(maxConns)
+	}
+	if value := getEnvWithDefault("DB_MIN_CONNS", ""); value != "" {
+		minConns, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || minConns < 0 {
+			return nil, fmt.Errorf("invalid DB_MIN_CONNS %q", value)
+		}
+		config.MinConns = int32(minConns)
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, config.ConnConfig.Copy())
+	if err != nil {
+		return nil, err
+	}
+	err = migrateSchema(ctx, conn)
+	conn.Close(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		// Register pgvector types
+		if err := pgxvec.RegisterTypes(ctx, conn); err != nil {
+			return fmt.Errorf("failed to register pgvector types: %w", err)
+		}
+		for name, sql := range statements {
+			if _, err := conn.Prepare(ctx, name, sql); err != nil {
+				return fmt.Errorf("failed to prepare %s: %w", name, err)
+			}
+		}
+		return nil
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return &pgStore{pool: pool}, nil
+}
+
+func (s *pgStore) FindExact(ctx context.Context, sourceLang, targetLang string, glossaryID int64, hash string) (cacheEntry, bool, error) {
+	var entry cacheEntry
+	err := s.pool.QueryRow(ctx, stmtFindExact, sourceLang, targetLang, hash, glossaryID).Scan(&entry.ID, &entry.SourceText, &entry.TargetText)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entry, false, nil
+		}
+		return entry, false, err
+	}
+	return entry, true, nil
+}
+
+func (s *pgStore) FindNearest(ctx context.Context, sourceLang, targetLang string, glossaryID int64, model string, embedding []float32, threshold float64, limit int) ([]cacheEntry, error) {
+	rows, err := s.pool.Query(ctx, findNearestQuery(len(embedding)), sourceLang, targetLang, pgvector.NewVector(embedding), glossaryID, threshold, limit, model)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (cacheEntry, error) {
+		var entry cacheEntry
+		err := row.Scan(&entry.ID, &entry.SourceText, &entry.TargetText, &entry.Distance, &entry.Origin, &entry.Priority)
+		return entry, err
+	})
+}
+
+func (s *pgStore) Save(ctx context.Context, record cacheRecord) (int64, error) {
+	id, err := s.save(ctx, stmtSave, record)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	return id, err
+}
+
+func (s *pgStore) SaveOverride(ctx context.Context, record cacheRecord) (int64, error) {
+	return s.save(ctx, stmtSaveOverride, record)
+}
+
+// save inserts record with one of the save statements
+func (s *pgStore) save(ctx context.Context, stmt string, record cacheRecord) (int64, error) {
+	var id int64
+	err := s.pool.QueryRow(ctx, stmt,
+		record.SourceLanguage, record.TargetLanguage, pgvector.NewVector(record.Embedding),
+		record.TargetText, record.SourceText, record.SourceHash,
+		record.EmbeddingModel, len(record.Embedding), record.Origin, record.Priority, record.GlossaryID,
+	).Scan(&id)
+	return id, err
+}
+
+func (s *pgStore) FindStaleEmbeddings(ctx context.Context, model string, afterID int64, limit int) ([]cacheEntry, error) {
+	rows, err := s.pool.Query(ctx, stmtFindStaleEmbeddings, model, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (cacheEntry, error) {
+		var entry cacheEntry
+		err := row.Scan(&entry.ID, &entry.SourceText, &entry.TargetText)
+		return entry, err
+	})
+}
+
+func (s *pgStore) UpdateEmbedding(ctx context.Context, id int64, model string, embedding []float32) error {
+	_, err := s.pool.Exec(ctx, stmtUpdateEmbedding, id, model, pgvector.NewVector(embedding), len(embedding))
+	return err
+}
+
+func (s *pgStore) RecordHit(ctx context.Context, id int64) error {
+	_, err := s.pool.Exec(ctx, stmtRecordHit, id)
+	return err
+}
+
+func (s *pgStore) PairCounts(ctx context.Context) ([]pairCount, error) {
+	rows, err := s.pool.Query(ctx, stmtPairCounts)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (pairCount, error) {
+		var count pairCount
+		err := row.Scan(&count.SourceLanguage, &count.TargetLanguage, &count.Rows)
+		return count, err
+	})
+}
+
+func (s *pgStore) DeleteExpired(ctx context.Context, sourceLang, targetLang string, before time.Time, limit int) (int64, error) {
+	tag, err := s.pool.Exec(ctx, stmtDeleteExpired, sourceLang, targetLang, before, limit)
+	return tag.RowsAffected(), err
+}
+
+func (s *pgStore) DeleteLeastUsed(ctx context.Context, sourceLang, targetLang, policy string, limit int) (int64, error) {
+	stmt := stmtDeleteLRU
+	if policy == evictLFU {
+		stmt = stmtDeleteLFU
+	}
+	tag, err := s.pool.Exec(ctx, stmt, sourceLang, targetLang, limit)
+	return tag.RowsAffected(), err
+}
+
+func (s *pgStore) ListRows(ctx context.Context, filter cacheFilter, limit, offset int) ([]cacheRow, error) {
+	rows, err := s.pool.Query(ctx, stmtListRows, filter.SourceLanguage, filter.TargetLanguage, escapeLike(filter.Query), filter.Pattern, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, scanCacheRow)
+}
+
+func (s *pgStore) GetRow(ctx context.Context, id int64) (cacheRow, bool, error) {
+	rows, err := s.pool.Query(ctx, stmtGetRow, id)
+	if err != nil {
+		return cacheRow{}, false, err
+	}
+	row, err := pgx.CollectExactlyOneRow(rows, scanCacheRow)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return row, false, nil
+	}
+	return row, err == nil, err
+}
+
+func (s *pgStore) UpdateTargetText(ctx context.Context, id int64, targetText string) (bool, error) {
+	tag, err := s.pool.Exec(ctx, stmtUpdateTargetText, id, targetText)
+	return tag.RowsAffected() > 0, err
+}
+
+func (s *pgStore) DeleteRow(ctx context.Context, id int64) (bool, error) {
+	tag, err := s.pool.Exec(ctx, stmtDeleteRow, id)
+	return tag.RowsAffected() > 0, err
+}
+
+func (s *pgStore) DeleteRows(ctx context.Context, filter cacheFilter) (int64, error) {
+	tag, err := s.pool.Exec(ctx, stmtDeleteRows, filter.SourceLanguage, filter.TargetLanguage, escapeLike(filter.Query), filter.Pattern)
+	return tag.RowsAffected(), err
+}
+
+func (s *pgStore) PairStats(ctx context.Context) ([]pairStats, error) {
+	rows, err := s.pool.Query(ctx, stmtPairStats)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (pairStats, error) {
+		var stats pairStats
+		err := row.Scan(&stats.SourceLanguage, &stats.TargetLanguage, &stats.Entries, &stats.HitEntries,
+			&stats.Hits, &stats.OldestAt, &stats.NewestAt, &stats.LastHitAt)
+		return stats, err
+	})
+}
+
+func (s *pgStore) FindGlossary(ctx context.Context, tenant, sourceLang, targetLang string) (glossary, bool, error) {
+	rows, err := s.pool.Query(ctx, stmtFindGlossary, tenant, sourceLang, targetLang)
+	if err != nil {
+		return glossary{}, false, err
+	}
+	return s.withTerms(ctx, rows)
+}
+
+func (s *pgStore) ListGlossaries(ctx context.Context) ([]glossary, error) {
+	rows, err := s.pool.Query(ctx, stmtListGlossaries)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, scanGlossary)
+}
+
+func (s *pgStore) GetGlossary(ctx context.Context, id int64) (glossary, bool, error) {
+	rows, err := s.pool.Query(ctx, stmtGetGlossary, id)
+	if err != nil {
+		return glossary{}, false, err
+	}
+	return s.withTerms(ctx, rows)
+}
+
+// withTerms collects the single glossary of rows, if any, and its terms
+func (s *pgStore) withTerms(ctx context.Context, rows pgx.Rows) (glossary, bool, error) {
+	g, err := pgx.CollectExactlyOneRow(rows, scanGlossary)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return g, false, nil
+	}
+	if err != nil {
+		return g, false, err
+	}
+	rows, err = s.pool.Query(ctx, stmtGlossaryTerms, g.ID)
+	if err != nil {
+		return g, false, err
+	}
+	g.Terms, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (glossaryTerm, error) {
+		var term glossaryTerm
+		err := row.Scan(&term.SourceTerm, &term.TargetTerm, &term.IgnoreCase)
+		return term, err
+	})
+	return g, err == nil, err
+}
+
+func (s *pgStore) CreateGlossary(ctx context.Context, g glossary) (int64, error) {
+	var id int64
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, stmtCreateGlossary, g.Tenant, g.SourceLanguage, g.TargetLanguage).Scan(&id); err != nil {
+			return err
+		}
+		return insertGlossaryTerms(ctx, tx, id, g.Terms)
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return 0, errGlossaryExists
+	}
+	return id, err
+}
+
+func (s *pgStore) ReplaceGlossaryTerms(ctx context.Context, id int64, terms []glossaryTerm) ([]int64, bool, error) {
+	found := false
+	var pinned []int64
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, stmtTouchGlossary, id)
+		if err != nil || tag.RowsAffected() == 0 {
+			return err
+		}
+		found = true
+
+		rows, err := tx.Query(ctx, stmtGlossaryTerms, id)
+		if err != nil {
+			return err
+		}
+		previous, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (glossaryTerm, error) {
+			var term glossaryTerm
+			err := row.Scan(&term.SourceTerm, &term.TargetTerm, &term.IgnoreCase)
+			return term, err
+		})
+		if err != nil {
+			return err
+		}
+		if !sameSourceTerms(previous, terms) {
+			if pinned, err = pinnedCorrections(ctx, tx, id); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(ctx, stmtDeleteGlossaryTerms, id); err != nil {
+			return err
+		}
+		return insertGlossaryTerms(ctx, tx, id, terms)
+	})
+	if !found || err != nil {
+		return nil, false, err
+	}
+	return pinned, true, nil
+}
+
+func (s *pgStore) DeleteGlossary(ctx context.Context, id int64) ([]int64, bool, error) {
+	found := false
+	var pinned []int64
+	err := pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, stmtDeleteGlossary, id)
+		if err != nil || tag.RowsAffected() == 0 {
+			return err
+		}
+		found = true
+		if _, err := tx.Exec(ctx, stmtDeleteGlossaryCache, id); err != nil {
+			return err
+		}
+		pinned, err = pinnedCorrections(ctx, tx, id)
+		return err
+	})
+	if !found || err != nil {
+		return nil, false, err
+	}
+	return pinned, true, nil
+}
+
+// pinnedCorrections returns the ids of the human-approved and imported
+// entries cached with a glossary
+func pinnedCorrections(ctx context.Context, tx pgx.Tx, id int64) ([]int64, error) {
+	rows, err := tx.Query(ctx, stmtPinnedCorrections, id)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[int64])
+}
+
+// sameSourceTerms reports whether two term lists mask the same text, which
+// is the case when only their target terms differ
+func sameSourceTerms(a, b []glossaryTerm) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sources := make(map[glossaryTerm]bool, len(a))
+	for _, term := range a {
+		sources[glossaryTerm{SourceTerm: term.SourceTerm, IgnoreCase: term.IgnoreCase}] = true
+	}
+	for _, term := range b {
+		if !sources[glossaryTerm{SourceTerm: term.SourceTerm, IgnoreCase: term.IgnoreCase}] {
+			return false
+		}
+	}
+	return true
+}
+
+// insertGlossaryTerms inserts the terms of a glossary in one statement
+func insertGlossaryTerms(ctx context.Context, tx pgx.Tx, id int64, terms []glossaryTerm) error {
+	sources := make([]string, len(terms))
+	targets := make([]string, len(terms))
+	ignoreCase := make([]bool, len(terms))
+	for i, term := range terms {
+		sources[i], targets[i], ignoreCase[i] = term.SourceTerm, term.TargetTerm, term.IgnoreCase
+	}
+	_, err := tx.Exec(ctx, stmtInsertGlossaryTerms, id, sources, targets, ignoreCase)
+	return err
+}
+
+// scanGlossary scans the glossaryColumns of a row
+func scanGlossary(row pgx.CollectableRow) (glossary, error) {
+	var g glossary
+	err := row.Scan(&g.ID, &g.Tenant, &g.SourceLanguage, &g.TargetLanguage, &g.CreatedAt, &g.UpdatedAt)
+	return g, err
+}
+
+// scanCacheRow scans the cacheRowColumns of a row
+func scanCacheRow(row pgx.CollectableRow) (cacheRow, error) {
+	var r cacheRow
+	err := row.Scan(&r.ID, &r.SourceLanguage, &r.TargetLanguage, &r.SourceText, &r.TargetText,
+		&r.GlossaryID, &r.EmbeddingModel, &r.Origin, &r.Priority, &r.CreatedAt, &r.LastHitAt, &r.HitCount)
+	return r, err
+}
+
+// escapeLike escapes the LIKE wildcards in a literal substring
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func (s *pgStore) Close() {
+	s.pool.Close()
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// segmentEvent reports a sentence as soon as it has been translated
+type segmentEvent struct {
+	Index       int    `json:"index"`
+	Source      string `json:"source"`
+	Translation string `json:"translation"`
+	Leading     string `json:"leading,omitempty"`
+	Trailing    string `json:"trailing"`
+	Cached      bool   `json:"cached"`
+	ElapsedMs   int64  `json:"elapsed_ms"`
+	// Masked spans the translation dropped or repeated
+	Missing    []string `json:"missing,omitempty"`
+	Duplicated []string `json:"duplicated,omitempty"`
+}
+
+// handleTranslateStream handles the /translate/stream endpoint, sending each
+// translated sentence as a Server-Sent Event in completion order, followed by
+// a "done" event with the assembled translation
+func handleTranslateStream(w http.ResponseWriter, r *http.Request) {
+	defer recoverFromPanic(w, r)
+
+	if r.Method != http.MethodPost {
+		writeError(w, r, methodNotAllowed())
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, r, internalError(errors.New("response writer does not support streaming")))
+		return
+	}
+
+	request, ok := decodeTranslateRequest(w, r)
+	if !ok {
+		return
+	}
+
+	// The status is only sent once the request is known to be translatable,
+	// so that an undetected language or unsupported pair is a plain 400
+	started := false
+	opts := request.options()
+	opts.OnStart = func() {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+		started = true
+	}
+	opts.OnSegment = func(event segmentEvent) {
+		writeEvent(w, flusher, "segment", event)
+	}
+
+	result, err := processTranslation(r.Context(), request.Text, request.SourceLanguage, request.TargetLanguage, opts)
+	if err != nil && !started {
+		writeError(w, r, classifyError(err))
+		return
+	}
+	if err != nil {
+		// The status has already been sent, so the error goes in the stream
+		e := classifyError(err)
+		e.RequestID = requestID(r.Header.Get("X-Request-ID"))
+		e.log()
+		writeEvent(w, flusher, "error", map[string]apiError{"error": e})
+		return
+	}
+
+	done := map[string]interface{}{
+		"translation": result.Translation,
+		"distance":    result.Distance,
+		"route":       result.Route,
+	}
+	if result.DetectedLanguage != "" {
+		done["detected_language"] = result.DetectedLanguage
+		done["detection_confidence"] = result.DetectionConfidence
+	}
+	if result.Flagged != nil {
+		done["flagged"] = result.Flagged
+	}
+	writeEvent(w, flusher, "done", done)
+}
+
+// writeEvent writes a single Server-Sent Event with a JSON payload
+func writeEvent(w http.ResponseWriter, flusher http.Flusher, event string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Error encoding event: %v", err)
+		return
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		log.Printf("Error writing event: %v", err)
+		return
+	}
+	flusher.Flush()
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandleTranslateStream(t *testing.T) {
+	setupPipeline(t, &fakeTranslator{Pairs: []languagePair{{SourceLanguage: "en", TargetLanguage: "es"}}})
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantType    string
+		wantContent string
+	}{
+		{"supported pair", `{"text": "Hello world.", "source_language": "en", "target_language": "es"}`, http.StatusOK, "text/event-stream", "event: done"},
+		{"unsupported pair", `{"text": "Hello world.", "source_language": "de", "target_language": "fr"}`, http.StatusBadRequest, "application/json", codeUnsupportedPair},
+		{"undetected language", `{"text": "12345", "target_language": "es"}`, http.StatusBadRequest, "application/json", codeUndetectedLanguage},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handleTranslateStream(recorder, httptest.NewRequest(http.MethodPost, "/translate/stream", strings.NewReader(test.body)))
+			if recorder.Code != test.wantStatus || recorder.Header().Get("Content-Type") != test.wantType || !strings.Contains(recorder.Body.String(), test.wantContent) {
+				t.Errorf("got %d %s %q, want %d %s containing %q", recorder.Code, recorder.Header().Get("Content-Type"), recorder.Body.String(), test.wantStatus, test.wantType, test.wantContent)
+			}
+		})
+	}
+}
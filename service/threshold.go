@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultSimilarityThreshold is the maximum cosine distance for a cache hit
+// when SIMILARITY_THRESHOLD is not set
+const defaultSimilarityThreshold = 0.1
+
+var (
+	similarityThresholdDefault float64
+	similarityThresholds       map[string]float64
+)
+
+// loadSimilarityThresholds reads the global threshold from SIMILARITY_THRESHOLD
+// and per language pair overrides from SIMILARITY_THRESHOLDS, formatted as
+// "en:es=0.05,en:zh=0.08"
+func loadSimilarityThresholds() error {
+	var err error
+	similarityThresholdDefault, err = parseDistance(getEnvWithDefault("SIMILARITY_THRESHOLD", strconv.FormatFloat(defaultSimilarityThreshold, 'f', -1, 64)))
+	if err != nil {
+		return fmt.Errorf("invalid SIMILARITY_THRESHOLD: %w", err)
+	}
+
+	similarityThresholds = make(map[string]float64)
+	return parsePairSettings("SIMILARITY_THRESHOLDS", func(pair, value string) error {
+		threshold, err := parseDistance(value)
+		if err != nil {
+			return err
+		}
+		similarityThresholds[pair] = threshold
+		return nil
+	})
+}
+
+// similarityThreshold returns the maximum cosine distance for a cache hit for
+// a language pair, tightened by the request's limit if one is given
+func similarityThreshold(sourceLang, targetLang string, requested float64) float64 {
+	threshold, ok := similarityThresholds[pairKey(sourceLang, targetLang)]
+	if !ok {
+		threshold = similarityThresholdDefault
+	}
+	if requested > 0 && requested < threshold {
+		return requested
+	}
+	return threshold
+}
+
+// pairKey returns the key identifying a language pair
+func pairKey(sourceLang, targetLang string) string {
+	return strings.TrimSpace(sourceLang) + ":" + strings.TrimSpace(targetLang)
+}
+
+// parsePairSettings calls set for every "src:tgt=value" entry of the
+// environment variable key
+func parsePairSettings(key string, set func(pair, value string) error) error {
+	for _, entry := range strings.Split(getEnvWithDefault(key, ""), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pair, value, ok := strings.Cut(entry, "=")
+		sourceLang, targetLang, okPair := strings.Cut(pair, ":")
+		if !ok || !okPair || sourceLang == "" || targetLang == "" {
+			return fmt.Errorf("invalid %s entry %q", key, entry)
+		}
+		if err := set(pairKey(sourceLang, targetLang), strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("invalid %s entry %q: %w", key, entry, err)
+		}
+	}
+	return nil
+}
+
+// parseDistance parses a cosine distance, which must lie between 0 and 2
+func parseDistance(value string) (float64, error) {
+	distance, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+	if distance < 0 || distance > 2 {
+		return 0, fmt.Errorf("cosine distance %v out of range [0, 2]", distance)
+	}
+	return distance, nil
+}
@@ -25,8 +25,13 @@ const (
 type TranslationRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Text           string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`                                           // The text to be translated.
-	SourceLanguage string                 `protobuf:"bytes,2,opt,name=source_language,json=sourceLanguage,proto3" json:"source_language,omitempty"` // The source language code (e.g., "en").
+	SourceLanguage string                 `protobuf:"bytes,2,opt,name=source_language,json=sourceLanguage,proto3" json:"source_language,omitempty"` // The source language code (e.g., "en"); empty or "auto" to detect it.
 	TargetLanguage string                 `protobuf:"bytes,3,opt,name=target_language,json=targetLanguage,proto3" json:"target_language,omitempty"` // The target language code (e.g., "es").
+	MaxDistance    float64                `protobuf:"fixed64,4,opt,name=max_distance,json=maxDistance,proto3" json:"max_distance,omitempty"`        // Optional cosine distance limit for cache hits; can only tighten the configured threshold.
+	ExactOnly      bool                   `protobuf:"varint,5,opt,name=exact_only,json=exactOnly,proto3" json:"exact_only,omitempty"`               // Only reuse cached translations of the exact same text.
+	Debug          bool                   `protobuf:"varint,6,opt,name=debug,proto3" json:"debug,omitempty"`                                        // Return the cache candidates considered for each sentence.
+	Verbose        bool                   `protobuf:"varint,7,opt,name=verbose,proto3" json:"verbose,omitempty"`                                    // Return how each sentence was translated.
+	Tenant         string                 `protobuf:"bytes,8,opt,name=tenant,proto3" json:"tenant,omitempty"`                                       // Selects the tenant's glossary; empty for the default glossary.
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -82,17 +87,294 @@ func (x *TranslationRequest) GetTargetLanguage() string {
 	return ""
 }
 
-// The response message containing the translated text.
-type TranslationResponse struct {
+func (x *TranslationRequest) GetMaxDistance() float64 {
+	if x != nil {
+		return x.MaxDistance
+	}
+	return 0
+}
+
+func (x *TranslationRequest) GetExactOnly() bool {
+	if x != nil {
+		return x.ExactOnly
+	}
+	return false
+}
+
+func (x *TranslationRequest) GetDebug() bool {
+	if x != nil {
+		return x.Debug
+	}
+	return false
+}
+
+func (x *TranslationRequest) GetVerbose() bool {
+	if x != nil {
+		return x.Verbose
+	}
+	return false
+}
+
+func (x *TranslationRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// A cached translation considered for a sentence, returned in debug mode.
+type CacheCandidate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Translation   string                 `protobuf:"bytes,1,opt,name=translation,proto3" json:"translation,omitempty"` // The translated text.
+	Sentence      int32                  `protobuf:"varint,1,opt,name=sentence,proto3" json:"sentence,omitempty"`                      // The index of the sentence the candidate was considered for.
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`                                  // The cache row id.
+	SourceText    string                 `protobuf:"bytes,3,opt,name=source_text,json=sourceText,proto3" json:"source_text,omitempty"` // The cached source text.
+	TargetText    string                 `protobuf:"bytes,4,opt,name=target_text,json=targetText,proto3" json:"target_text,omitempty"` // The cached translation.
+	Distance      float64                `protobuf:"fixed64,5,opt,name=distance,proto3" json:"distance,omitempty"`                     // The cosine distance to the sentence.
+	Score         float64                `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`                           // The scoring policy result, lower is better.
+	Outcome       string                 `protobuf:"bytes,7,opt,name=outcome,proto3" json:"outcome,omitempty"`                         // The verification outcome (e.g., "accepted", "rejected_number").
+	Selected      bool                   `protobuf:"varint,8,opt,name=selected,proto3" json:"selected,omitempty"`                      // Whether the candidate was used.
+	Source        string                 `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"`                           // Where the cached translation came from: "machine", "human" or "imported".
+	Priority      int32                  `protobuf:"varint,10,opt,name=priority,proto3" json:"priority,omitempty"`                     // The priority of the cached translation in the scoring.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *CacheCandidate) Reset() {
+	*x = CacheCandidate{}
+	mi := &file_translate_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheCandidate) ProtoMessage() {}
+
+func (x *CacheCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheCandidate.ProtoReflect.Descriptor instead.
+func (*CacheCandidate) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CacheCandidate) GetSentence() int32 {
+	if x != nil {
+		return x.Sentence
+	}
+	return 0
+}
+
+func (x *CacheCandidate) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CacheCandidate) GetSourceText() string {
+	if x != nil {
+		return x.SourceText
+	}
+	return ""
+}
+
+func (x *CacheCandidate) GetTargetText() string {
+	if x != nil {
+		return x.TargetText
+	}
+	return ""
+}
+
+func (x *CacheCandidate) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *CacheCandidate) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *CacheCandidate) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *CacheCandidate) GetSelected() bool {
+	if x != nil {
+		return x.Selected
+	}
+	return false
+}
+
+func (x *CacheCandidate) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *CacheCandidate) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+// How one sentence was translated on one leg of the route, returned in verbose mode.
+type SegmentDetail struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Index          int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                                        // The index of the sentence.
+	SourceLanguage string                 `protobuf:"bytes,2,opt,name=source_language,json=sourceLanguage,proto3" json:"source_language,omitempty"` // The source language of the leg.
+	TargetLanguage string                 `protobuf:"bytes,3,opt,name=target_language,json=targetLanguage,proto3" json:"target_language,omitempty"` // The target language of the leg.
+	Source         string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`                                       // The sentence translated on this leg.
+	Translation    string                 `protobuf:"bytes,5,opt,name=translation,proto3" json:"translation,omitempty"`                             // The translated sentence.
+	Cache          string                 `protobuf:"bytes,6,opt,name=cache,proto3" json:"cache,omitempty"`                                         // The cache status: "exact", "semantic" or "miss".
+	Distance       float64                `protobuf:"fixed64,7,opt,name=distance,proto3" json:"distance,omitempty"`                                 // The cosine distance of a semantic hit.
+	CacheId        int64                  `protobuf:"varint,8,opt,name=cache_id,json=cacheId,proto3" json:"cache_id,omitempty"`                     // The cache row served or saved.
+	LatencyMs      int64                  `protobuf:"varint,9,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`               // Milliseconds from the start of the leg until the sentence completed.
+	Backend        string                 `protobuf:"bytes,10,opt,name=backend,proto3" json:"backend,omitempty"`                                    // The backend that produced the translation, "cache" for hits.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SegmentDetail) Reset() {
+	*x = SegmentDetail{}
+	mi := &file_translate_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SegmentDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentDetail) ProtoMessage() {}
+
+func (x *SegmentDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentDetail.ProtoReflect.Descriptor instead.
+func (*SegmentDetail) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SegmentDetail) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SegmentDetail) GetSourceLanguage() string {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return ""
+}
+
+func (x *SegmentDetail) GetTargetLanguage() string {
+	if x != nil {
+		return x.TargetLanguage
+	}
+	return ""
+}
+
+func (x *SegmentDetail) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SegmentDetail) GetTranslation() string {
+	if x != nil {
+		return x.Translation
+	}
+	return ""
+}
+
+func (x *SegmentDetail) GetCache() string {
+	if x != nil {
+		return x.Cache
+	}
+	return ""
+}
+
+func (x *SegmentDetail) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *SegmentDetail) GetCacheId() int64 {
+	if x != nil {
+		return x.CacheId
+	}
+	return 0
+}
+
+func (x *SegmentDetail) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *SegmentDetail) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+// The response message containing the translated text.
+type TranslationResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Translation         string                 `protobuf:"bytes,1,opt,name=translation,proto3" json:"translation,omitempty"`                                              // The translated text.
+	Distance            float64                `protobuf:"fixed64,2,opt,name=distance,proto3" json:"distance,omitempty"`                                                  // The largest cosine distance of any cached sentence used, 0 if none were approximate.
+	Candidates          []*CacheCandidate      `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`                                                // The cache candidates considered, only set in debug mode.
+	DetectedLanguage    string                 `protobuf:"bytes,4,opt,name=detected_language,json=detectedLanguage,proto3" json:"detected_language,omitempty"`            // The detected source language, only set when it was omitted.
+	DetectionConfidence float64                `protobuf:"fixed64,5,opt,name=detection_confidence,json=detectionConfidence,proto3" json:"detection_confidence,omitempty"` // The confidence of the detection, between 0 and 1.
+	Route               []string               `protobuf:"bytes,6,rep,name=route,proto3" json:"route,omitempty"`                                                          // The languages translated through, from source to target, including any pivot.
+	Segments            []*SegmentDetail       `protobuf:"bytes,7,rep,name=segments,proto3" json:"segments,omitempty"`                                                    // How each sentence was translated, only set in verbose mode.
+	GlossaryId          int64                  `protobuf:"varint,8,opt,name=glossary_id,json=glossaryId,proto3" json:"glossary_id,omitempty"`                             // The glossary applied, 0 if none.
+	Flagged             []*FlaggedSegment      `protobuf:"bytes,9,rep,name=flagged,proto3" json:"flagged,omitempty"`                                                      // The sentences whose translation dropped or repeated a protected span.
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
 func (x *TranslationResponse) Reset() {
 	*x = TranslationResponse{}
-	mi := &file_translate_proto_msgTypes[1]
+	mi := &file_translate_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -104,7 +386,7 @@ func (x *TranslationResponse) String() string {
 func (*TranslationResponse) ProtoMessage() {}
 
 func (x *TranslationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_translate_proto_msgTypes[1]
+	mi := &file_translate_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -117,7 +399,7 @@ func (x *TranslationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TranslationResponse.ProtoReflect.Descriptor instead.
 func (*TranslationResponse) Descriptor() ([]byte, []int) {
-	return file_translate_proto_rawDescGZIP(), []int{1}
+	return file_translate_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *TranslationResponse) GetTranslation() string {
@@ -127,20 +409,956 @@ func (x *TranslationResponse) GetTranslation() string {
 	return ""
 }
 
-var File_translate_proto protoreflect.FileDescriptor
+func (x *TranslationResponse) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
 
-const file_translate_proto_rawDesc = "" +
-	"\n" +
-	"\x0ftranslate.proto\x12\ttranslate\"z\n" +
-	"\x12TranslationRequest\x12\x12\n" +
-	"\x04text\x18\x01 \x01(\tR\x04text\x12'\n" +
-	"\x0fsource_language\x18\x02 \x01(\tR\x0esourceLanguage\x12'\n" +
-	"\x0ftarget_language\x18\x03 \x01(\tR\x0etargetLanguage\"7\n" +
-	"\x13TranslationResponse\x12 \n" +
-	"\vtranslation\x18\x01 \x01(\tR\vtranslation2X\n" +
+func (x *TranslationResponse) GetCandidates() []*CacheCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *TranslationResponse) GetDetectedLanguage() string {
+	if x != nil {
+		return x.DetectedLanguage
+	}
+	return ""
+}
+
+func (x *TranslationResponse) GetDetectionConfidence() float64 {
+	if x != nil {
+		return x.DetectionConfidence
+	}
+	return 0
+}
+
+func (x *TranslationResponse) GetRoute() []string {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+func (x *TranslationResponse) GetSegments() []*SegmentDetail {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *TranslationResponse) GetGlossaryId() int64 {
+	if x != nil {
+		return x.GlossaryId
+	}
+	return 0
+}
+
+func (x *TranslationResponse) GetFlagged() []*FlaggedSegment {
+	if x != nil {
+		return x.Flagged
+	}
+	return nil
+}
+
+// A sentence whose translation dropped or repeated placeholders, code spans, entities or glossary terms.
+type FlaggedSegment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`          // The index of the sentence.
+	Missing       []string               `protobuf:"bytes,2,rep,name=missing,proto3" json:"missing,omitempty"`       // The spans missing from the translation.
+	Duplicated    []string               `protobuf:"bytes,3,rep,name=duplicated,proto3" json:"duplicated,omitempty"` // The spans that appear more than once in the translation.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlaggedSegment) Reset() {
+	*x = FlaggedSegment{}
+	mi := &file_translate_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlaggedSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlaggedSegment) ProtoMessage() {}
+
+func (x *FlaggedSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlaggedSegment.ProtoReflect.Descriptor instead.
+func (*FlaggedSegment) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FlaggedSegment) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *FlaggedSegment) GetMissing() []string {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+func (x *FlaggedSegment) GetDuplicated() []string {
+	if x != nil {
+		return x.Duplicated
+	}
+	return nil
+}
+
+// A single independently translated item within a batch request.
+type BatchTranslationItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                               // Caller-supplied identifier echoed back in the result.
+	Text           string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`                                           // The text to be translated.
+	SourceLanguage string                 `protobuf:"bytes,3,opt,name=source_language,json=sourceLanguage,proto3" json:"source_language,omitempty"` // The source language code (e.g., "en"); empty or "auto" to detect it.
+	TargetLanguage string                 `protobuf:"bytes,4,opt,name=target_language,json=targetLanguage,proto3" json:"target_language,omitempty"` // The target language code (e.g., "es").
+	Tenant         string                 `protobuf:"bytes,5,opt,name=tenant,proto3" json:"tenant,omitempty"`                                       // Selects the tenant's glossary; empty for the default glossary.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchTranslationItem) Reset() {
+	*x = BatchTranslationItem{}
+	mi := &file_translate_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTranslationItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTranslationItem) ProtoMessage() {}
+
+func (x *BatchTranslationItem) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTranslationItem.ProtoReflect.Descriptor instead.
+func (*BatchTranslationItem) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchTranslationItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchTranslationItem) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *BatchTranslationItem) GetSourceLanguage() string {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return ""
+}
+
+func (x *BatchTranslationItem) GetTargetLanguage() string {
+	if x != nil {
+		return x.TargetLanguage
+	}
+	return ""
+}
+
+func (x *BatchTranslationItem) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// The request message containing the items to be translated.
+type BatchTranslationRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Items         []*BatchTranslationItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // The items to be translated.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTranslationRequest) Reset() {
+	*x = BatchTranslationRequest{}
+	mi := &file_translate_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTranslationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTranslationRequest) ProtoMessage() {}
+
+func (x *BatchTranslationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTranslationRequest.ProtoReflect.Descriptor instead.
+func (*BatchTranslationRequest) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchTranslationRequest) GetItems() []*BatchTranslationItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// The outcome of translating a single batch item.
+type BatchTranslationResult struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                // The identifier of the corresponding request item.
+	Translation         string                 `protobuf:"bytes,2,opt,name=translation,proto3" json:"translation,omitempty"`                                              // The translated text, empty if the item failed.
+	Error               string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                                          // The reason the item failed, empty on success.
+	ErrorCode           string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`                                 // The stable error code of a failed item, e.g. "unsupported_language_pair".
+	Retryable           bool                   `protobuf:"varint,5,opt,name=retryable,proto3" json:"retryable,omitempty"`                                                 // Whether retrying a failed item may succeed.
+	Flagged             []*FlaggedSegment      `protobuf:"bytes,6,rep,name=flagged,proto3" json:"flagged,omitempty"`                                                      // The sentences whose translation dropped or repeated a protected span.
+	DetectedLanguage    string                 `protobuf:"bytes,7,opt,name=detected_language,json=detectedLanguage,proto3" json:"detected_language,omitempty"`            // The detected source language, set when the item's source_language was omitted.
+	DetectionConfidence float64                `protobuf:"fixed64,8,opt,name=detection_confidence,json=detectionConfidence,proto3" json:"detection_confidence,omitempty"` // The confidence of the detected source language, between 0 and 1.
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BatchTranslationResult) Reset() {
+	*x = BatchTranslationResult{}
+	mi := &file_translate_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTranslationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTranslationResult) ProtoMessage() {}
+
+func (x *BatchTranslationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTranslationResult.ProtoReflect.Descriptor instead.
+func (*BatchTranslationResult) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchTranslationResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchTranslationResult) GetTranslation() string {
+	if x != nil {
+		return x.Translation
+	}
+	return ""
+}
+
+func (x *BatchTranslationResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchTranslationResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *BatchTranslationResult) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+func (x *BatchTranslationResult) GetFlagged() []*FlaggedSegment {
+	if x != nil {
+		return x.Flagged
+	}
+	return nil
+}
+
+func (x *BatchTranslationResult) GetDetectedLanguage() string {
+	if x != nil {
+		return x.DetectedLanguage
+	}
+	return ""
+}
+
+func (x *BatchTranslationResult) GetDetectionConfidence() float64 {
+	if x != nil {
+		return x.DetectionConfidence
+	}
+	return 0
+}
+
+// The response message containing one result per request item, in request order.
+type BatchTranslationResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Results       []*BatchTranslationResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // The per-item results.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTranslationResponse) Reset() {
+	*x = BatchTranslationResponse{}
+	mi := &file_translate_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTranslationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTranslationResponse) ProtoMessage() {}
+
+func (x *BatchTranslationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTranslationResponse.ProtoReflect.Descriptor instead.
+func (*BatchTranslationResponse) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchTranslationResponse) GetResults() []*BatchTranslationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// The request message containing segments to translate between one language pair.
+type TranslationBatchRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Texts          []string               `protobuf:"bytes,1,rep,name=texts,proto3" json:"texts,omitempty"`                                         // The segments to be translated.
+	SourceLanguage string                 `protobuf:"bytes,2,opt,name=source_language,json=sourceLanguage,proto3" json:"source_language,omitempty"` // The source language code (e.g., "en"); empty or "auto" to detect it.
+	TargetLanguage string                 `protobuf:"bytes,3,opt,name=target_language,json=targetLanguage,proto3" json:"target_language,omitempty"` // The target language code (e.g., "es").
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TranslationBatchRequest) Reset() {
+	*x = TranslationBatchRequest{}
+	mi := &file_translate_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranslationBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslationBatchRequest) ProtoMessage() {}
+
+func (x *TranslationBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslationBatchRequest.ProtoReflect.Descriptor instead.
+func (*TranslationBatchRequest) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TranslationBatchRequest) GetTexts() []string {
+	if x != nil {
+		return x.Texts
+	}
+	return nil
+}
+
+func (x *TranslationBatchRequest) GetSourceLanguage() string {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return ""
+}
+
+func (x *TranslationBatchRequest) GetTargetLanguage() string {
+	if x != nil {
+		return x.TargetLanguage
+	}
+	return ""
+}
+
+// The response message containing one translation per segment, in request order.
+type TranslationBatchResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Translations         []string               `protobuf:"bytes,1,rep,name=translations,proto3" json:"translations,omitempty"`                                                      // The translated segments; empty for failed segments.
+	Errors               []*SegmentError        `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`                                                                  // The segments that could not be translated.
+	DetectedLanguages    []string               `protobuf:"bytes,3,rep,name=detected_languages,json=detectedLanguages,proto3" json:"detected_languages,omitempty"`                   // The detected language of each segment, set when source_language was omitted.
+	DetectionConfidences []float64              `protobuf:"fixed64,4,rep,packed,name=detection_confidences,json=detectionConfidences,proto3" json:"detection_confidences,omitempty"` // The confidence of each detected language, between 0 and 1.
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TranslationBatchResponse) Reset() {
+	*x = TranslationBatchResponse{}
+	mi := &file_translate_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranslationBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslationBatchResponse) ProtoMessage() {}
+
+func (x *TranslationBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslationBatchResponse.ProtoReflect.Descriptor instead.
+func (*TranslationBatchResponse) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TranslationBatchResponse) GetTranslations() []string {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *TranslationBatchResponse) GetErrors() []*SegmentError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *TranslationBatchResponse) GetDetectedLanguages() []string {
+	if x != nil {
+		return x.DetectedLanguages
+	}
+	return nil
+}
+
+func (x *TranslationBatchResponse) GetDetectionConfidences() []float64 {
+	if x != nil {
+		return x.DetectionConfidences
+	}
+	return nil
+}
+
+// A segment that could not be translated.
+type SegmentError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                         // The position of the segment in the request.
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`                          // Why the segment failed.
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"` // A stable, machine-readable error code.
+	Retryable     bool                   `protobuf:"varint,4,opt,name=retryable,proto3" json:"retryable,omitempty"`                 // Whether retrying the segment may succeed.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SegmentError) Reset() {
+	*x = SegmentError{}
+	mi := &file_translate_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SegmentError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentError) ProtoMessage() {}
+
+func (x *SegmentError) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentError.ProtoReflect.Descriptor instead.
+func (*SegmentError) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SegmentError) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SegmentError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SegmentError) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *SegmentError) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+// A translated sentence emitted by the streaming translation endpoint.
+type TranslationSegment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`                          // The position of the sentence in the input.
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`                         // The source sentence.
+	Translation   string                 `protobuf:"bytes,3,opt,name=translation,proto3" json:"translation,omitempty"`               // The translated sentence.
+	Leading       string                 `protobuf:"bytes,4,opt,name=leading,proto3" json:"leading,omitempty"`                       // Whitespace preceding the sentence in the input, only set on the first sentence.
+	Trailing      string                 `protobuf:"bytes,5,opt,name=trailing,proto3" json:"trailing,omitempty"`                     // Whitespace following the sentence in the input.
+	Cached        bool                   `protobuf:"varint,6,opt,name=cached,proto3" json:"cached,omitempty"`                        // Whether the translation was served from the cache.
+	ElapsedMs     int64                  `protobuf:"varint,7,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"` // Milliseconds since the request started when the sentence completed.
+	Missing       []string               `protobuf:"bytes,8,rep,name=missing,proto3" json:"missing,omitempty"`                       // Protected spans the translation dropped.
+	Duplicated    []string               `protobuf:"bytes,9,rep,name=duplicated,proto3" json:"duplicated,omitempty"`                 // Protected spans the translation repeated.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranslationSegment) Reset() {
+	*x = TranslationSegment{}
+	mi := &file_translate_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranslationSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranslationSegment) ProtoMessage() {}
+
+func (x *TranslationSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranslationSegment.ProtoReflect.Descriptor instead.
+func (*TranslationSegment) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TranslationSegment) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TranslationSegment) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *TranslationSegment) GetTranslation() string {
+	if x != nil {
+		return x.Translation
+	}
+	return ""
+}
+
+func (x *TranslationSegment) GetLeading() string {
+	if x != nil {
+		return x.Leading
+	}
+	return ""
+}
+
+func (x *TranslationSegment) GetTrailing() string {
+	if x != nil {
+		return x.Trailing
+	}
+	return ""
+}
+
+func (x *TranslationSegment) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+func (x *TranslationSegment) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *TranslationSegment) GetMissing() []string {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+func (x *TranslationSegment) GetDuplicated() []string {
+	if x != nil {
+		return x.Duplicated
+	}
+	return nil
+}
+
+// The request message for listing the supported language pairs.
+type ListLanguagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLanguagesRequest) Reset() {
+	*x = ListLanguagesRequest{}
+	mi := &file_translate_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLanguagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLanguagesRequest) ProtoMessage() {}
+
+func (x *ListLanguagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLanguagesRequest.ProtoReflect.Descriptor instead.
+func (*ListLanguagesRequest) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{13}
+}
+
+// A supported translation direction.
+type LanguagePair struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SourceLanguage string                 `protobuf:"bytes,1,opt,name=source_language,json=sourceLanguage,proto3" json:"source_language,omitempty"` // The source language code (e.g., "en").
+	TargetLanguage string                 `protobuf:"bytes,2,opt,name=target_language,json=targetLanguage,proto3" json:"target_language,omitempty"` // The target language code (e.g., "es").
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LanguagePair) Reset() {
+	*x = LanguagePair{}
+	mi := &file_translate_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LanguagePair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LanguagePair) ProtoMessage() {}
+
+func (x *LanguagePair) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LanguagePair.ProtoReflect.Descriptor instead.
+func (*LanguagePair) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LanguagePair) GetSourceLanguage() string {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return ""
+}
+
+func (x *LanguagePair) GetTargetLanguage() string {
+	if x != nil {
+		return x.TargetLanguage
+	}
+	return ""
+}
+
+// The response message containing the supported language pairs.
+type ListLanguagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pairs         []*LanguagePair        `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"` // The supported language pairs.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLanguagesResponse) Reset() {
+	*x = ListLanguagesResponse{}
+	mi := &file_translate_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLanguagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLanguagesResponse) ProtoMessage() {}
+
+func (x *ListLanguagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_translate_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLanguagesResponse.ProtoReflect.Descriptor instead.
+func (*ListLanguagesResponse) Descriptor() ([]byte, []int) {
+	return file_translate_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListLanguagesResponse) GetPairs() []*LanguagePair {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+var File_translate_proto protoreflect.FileDescriptor
+
+const file_translate_proto_rawDesc = "" +
+	"\n" +
+	"\x0ftranslate.proto\x12\ttranslate\"\x84\x02\n" +
+	"\x12TranslationRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12'\n" +
+	"\x0fsource_language\x18\x02 \x01(\tR\x0esourceLanguage\x12'\n" +
+	"\x0ftarget_language\x18\x03 \x01(\tR\x0etargetLanguage\x12!\n" +
+	"\fmax_distance\x18\x04 \x01(\x01R\vmaxDistance\x12\x1d\n" +
+	"\n" +
+	"exact_only\x18\x05 \x01(\bR\texactOnly\x12\x14\n" +
+	"\x05debug\x18\x06 \x01(\bR\x05debug\x12\x18\n" +
+	"\averbose\x18\a \x01(\bR\averbose\x12\x16\n" +
+	"\x06tenant\x18\b \x01(\tR\x06tenant\"\x9a\x02\n" +
+	"\x0eCacheCandidate\x12\x1a\n" +
+	"\bsentence\x18\x01 \x01(\x05R\bsentence\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vsource_text\x18\x03 \x01(\tR\n" +
+	"sourceText\x12\x1f\n" +
+	"\vtarget_text\x18\x04 \x01(\tR\n" +
+	"targetText\x12\x1a\n" +
+	"\bdistance\x18\x05 \x01(\x01R\bdistance\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x01R\x05score\x12\x18\n" +
+	"\aoutcome\x18\a \x01(\tR\aoutcome\x12\x1a\n" +
+	"\bselected\x18\b \x01(\bR\bselected\x12\x16\n" +
+	"\x06source\x18\t \x01(\tR\x06source\x12\x1a\n" +
+	"\bpriority\x18\n" +
+	" \x01(\x05R\bpriority\"\xb7\x02\n" +
+	"\rSegmentDetail\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12'\n" +
+	"\x0fsource_language\x18\x02 \x01(\tR\x0esourceLanguage\x12'\n" +
+	"\x0ftarget_language\x18\x03 \x01(\tR\x0etargetLanguage\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12 \n" +
+	"\vtranslation\x18\x05 \x01(\tR\vtranslation\x12\x14\n" +
+	"\x05cache\x18\x06 \x01(\tR\x05cache\x12\x1a\n" +
+	"\bdistance\x18\a \x01(\x01R\bdistance\x12\x19\n" +
+	"\bcache_id\x18\b \x01(\x03R\acacheId\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\t \x01(\x03R\tlatencyMs\x12\x18\n" +
+	"\abackend\x18\n" +
+	" \x01(\tR\abackend\"\x90\x03\n" +
+	"\x13TranslationResponse\x12 \n" +
+	"\vtranslation\x18\x01 \x01(\tR\vtranslation\x12\x1a\n" +
+	"\bdistance\x18\x02 \x01(\x01R\bdistance\x129\n" +
+	"\n" +
+	"candidates\x18\x03 \x03(\v2\x19.translate.CacheCandidateR\n" +
+	"candidates\x12+\n" +
+	"\x11detected_language\x18\x04 \x01(\tR\x10detectedLanguage\x121\n" +
+	"\x14detection_confidence\x18\x05 \x01(\x01R\x13detectionConfidence\x12\x14\n" +
+	"\x05route\x18\x06 \x03(\tR\x05route\x124\n" +
+	"\bsegments\x18\a \x03(\v2\x18.translate.SegmentDetailR\bsegments\x12\x1f\n" +
+	"\vglossary_id\x18\b \x01(\x03R\n" +
+	"glossaryId\x123\n" +
+	"\aflagged\x18\t \x03(\v2\x19.translate.FlaggedSegmentR\aflagged\"`\n" +
+	"\x0eFlaggedSegment\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x18\n" +
+	"\amissing\x18\x02 \x03(\tR\amissing\x12\x1e\n" +
+	"\n" +
+	"duplicated\x18\x03 \x03(\tR\n" +
+	"duplicated\"\xa4\x01\n" +
+	"\x14BatchTranslationItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12'\n" +
+	"\x0fsource_language\x18\x03 \x01(\tR\x0esourceLanguage\x12'\n" +
+	"\x0ftarget_language\x18\x04 \x01(\tR\x0etargetLanguage\x12\x16\n" +
+	"\x06tenant\x18\x05 \x01(\tR\x06tenant\"P\n" +
+	"\x17BatchTranslationRequest\x125\n" +
+	"\x05items\x18\x01 \x03(\v2\x1f.translate.BatchTranslationItemR\x05items\"\xb2\x02\n" +
+	"\x16BatchTranslationResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vtranslation\x18\x02 \x01(\tR\vtranslation\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12\x1c\n" +
+	"\tretryable\x18\x05 \x01(\bR\tretryable\x123\n" +
+	"\aflagged\x18\x06 \x03(\v2\x19.translate.FlaggedSegmentR\aflagged\x12+\n" +
+	"\x11detected_language\x18\a \x01(\tR\x10detectedLanguage\x121\n" +
+	"\x14detection_confidence\x18\b \x01(\x01R\x13detectionConfidence\"W\n" +
+	"\x18BatchTranslationResponse\x12;\n" +
+	"\aresults\x18\x01 \x03(\v2!.translate.BatchTranslationResultR\aresults\"\x81\x01\n" +
+	"\x17TranslationBatchRequest\x12\x14\n" +
+	"\x05texts\x18\x01 \x03(\tR\x05texts\x12'\n" +
+	"\x0fsource_language\x18\x02 \x01(\tR\x0esourceLanguage\x12'\n" +
+	"\x0ftarget_language\x18\x03 \x01(\tR\x0etargetLanguage\"\xd3\x01\n" +
+	"\x18TranslationBatchResponse\x12\"\n" +
+	"\ftranslations\x18\x01 \x03(\tR\ftranslations\x12/\n" +
+	"\x06errors\x18\x02 \x03(\v2\x17.translate.SegmentErrorR\x06errors\x12-\n" +
+	"\x12detected_languages\x18\x03 \x03(\tR\x11detectedLanguages\x123\n" +
+	"\x15detection_confidences\x18\x04 \x03(\x01R\x14detectionConfidences\"w\n" +
+	"\fSegmentError\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\x12\x1c\n" +
+	"\tretryable\x18\x04 \x01(\bR\tretryable\"\x8b\x02\n" +
+	"\x12TranslationSegment\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12 \n" +
+	"\vtranslation\x18\x03 \x01(\tR\vtranslation\x12\x18\n" +
+	"\aleading\x18\x04 \x01(\tR\aleading\x12\x1a\n" +
+	"\btrailing\x18\x05 \x01(\tR\btrailing\x12\x16\n" +
+	"\x06cached\x18\x06 \x01(\bR\x06cached\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\a \x01(\x03R\telapsedMs\x12\x18\n" +
+	"\amissing\x18\b \x03(\tR\amissing\x12\x1e\n" +
+	"\n" +
+	"duplicated\x18\t \x03(\tR\n" +
+	"duplicated\"\x16\n" +
+	"\x14ListLanguagesRequest\"`\n" +
+	"\fLanguagePair\x12'\n" +
+	"\x0fsource_language\x18\x01 \x01(\tR\x0esourceLanguage\x12'\n" +
+	"\x0ftarget_language\x18\x02 \x01(\tR\x0etargetLanguage\"F\n" +
+	"\x15ListLanguagesResponse\x12-\n" +
+	"\x05pairs\x18\x01 \x03(\v2\x17.translate.LanguagePairR\x05pairs2\xb5\x03\n" +
 	"\n" +
 	"Translator\x12J\n" +
-	"\tTranslate\x12\x1d.translate.TranslationRequest\x1a\x1e.translate.TranslationResponseB#Z!translationsapi/service;translateb\x06proto3"
+	"\tTranslate\x12\x1d.translate.TranslationRequest\x1a\x1e.translate.TranslationResponse\x12Y\n" +
+	"\x0eBatchTranslate\x12\".translate.BatchTranslationRequest\x1a#.translate.BatchTranslationResponse\x12Y\n" +
+	"\x0eTranslateBatch\x12\".translate.TranslationBatchRequest\x1a#.translate.TranslationBatchResponse\x12Q\n" +
+	"\x0fTranslateStream\x12\x1d.translate.TranslationRequest\x1a\x1d.translate.TranslationSegment0\x01\x12R\n" +
+	"\rListLanguages\x12\x1f.translate.ListLanguagesRequest\x1a .translate.ListLanguagesResponseB#Z!translationsapi/service;translateb\x06proto3"
 
 var (
 	file_translate_proto_rawDescOnce sync.Once
@@ -154,19 +1372,49 @@ func file_translate_proto_rawDescGZIP() []byte {
 	return file_translate_proto_rawDescData
 }
 
-var file_translate_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_translate_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_translate_proto_goTypes = []any{
-	(*TranslationRequest)(nil),  // 0: translate.TranslationRequest
-	(*TranslationResponse)(nil), // 1: translate.TranslationResponse
+	(*TranslationRequest)(nil),       // 0: translate.TranslationRequest
+	(*CacheCandidate)(nil),           // 1: translate.CacheCandidate
+	(*SegmentDetail)(nil),            // 2: translate.SegmentDetail
+	(*TranslationResponse)(nil),      // 3: translate.TranslationResponse
+	(*FlaggedSegment)(nil),           // 4: translate.FlaggedSegment
+	(*BatchTranslationItem)(nil),     // 5: translate.BatchTranslationItem
+	(*BatchTranslationRequest)(nil),  // 6: translate.BatchTranslationRequest
+	(*BatchTranslationResult)(nil),   // 7: translate.BatchTranslationResult
+	(*BatchTranslationResponse)(nil), // 8: translate.BatchTranslationResponse
+	(*TranslationBatchRequest)(nil),  // 9: translate.TranslationBatchRequest
+	(*TranslationBatchResponse)(nil), // 10: translate.TranslationBatchResponse
+	(*SegmentError)(nil),             // 11: translate.SegmentError
+	(*TranslationSegment)(nil),       // 12: translate.TranslationSegment
+	(*ListLanguagesRequest)(nil),     // 13: translate.ListLanguagesRequest
+	(*LanguagePair)(nil),             // 14: translate.LanguagePair
+	(*ListLanguagesResponse)(nil),    // 15: translate.ListLanguagesResponse
 }
 var file_translate_proto_depIdxs = []int32{
-	0, // 0: translate.Translator.Translate:input_type -> translate.TranslationRequest
-	1, // 1: translate.Translator.Translate:output_type -> translate.TranslationResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1,  // 0: translate.TranslationResponse.candidates:type_name -> translate.CacheCandidate
+	2,  // 1: translate.TranslationResponse.segments:type_name -> translate.SegmentDetail
+	4,  // 2: translate.TranslationResponse.flagged:type_name -> translate.FlaggedSegment
+	5,  // 3: translate.BatchTranslationRequest.items:type_name -> translate.BatchTranslationItem
+	4,  // 4: translate.BatchTranslationResult.flagged:type_name -> translate.FlaggedSegment
+	7,  // 5: translate.BatchTranslationResponse.results:type_name -> translate.BatchTranslationResult
+	11, // 6: translate.TranslationBatchResponse.errors:type_name -> translate.SegmentError
+	14, // 7: translate.ListLanguagesResponse.pairs:type_name -> translate.LanguagePair
+	0,  // 8: translate.Translator.Translate:input_type -> translate.TranslationRequest
+	6,  // 9: translate.Translator.BatchTranslate:input_type -> translate.BatchTranslationRequest
+	9,  // 10: translate.Translator.TranslateBatch:input_type -> translate.TranslationBatchRequest
+	0,  // 11: translate.Translator.TranslateStream:input_type -> translate.TranslationRequest
+	13, // 12: translate.Translator.ListLanguages:input_type -> translate.ListLanguagesRequest
+	3,  // 13: translate.Translator.Translate:output_type -> translate.TranslationResponse
+	8,  // 14: translate.Translator.BatchTranslate:output_type -> translate.BatchTranslationResponse
+	10, // 15: translate.Translator.TranslateBatch:output_type -> translate.TranslationBatchResponse
+	12, // 16: translate.Translator.TranslateStream:output_type -> translate.TranslationSegment
+	15, // 17: translate.Translator.ListLanguages:output_type -> translate.ListLanguagesResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_translate_proto_init() }
@@ -180,7 +1428,7 @@ func file_translate_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_translate_proto_rawDesc), len(file_translate_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
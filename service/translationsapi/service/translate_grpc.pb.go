@@ -19,7 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Translator_Translate_FullMethodName = "/translate.Translator/Translate"
+	Translator_Translate_FullMethodName       = "/translate.Translator/Translate"
+	Translator_BatchTranslate_FullMethodName  = "/translate.Translator/BatchTranslate"
+	Translator_TranslateBatch_FullMethodName  = "/translate.Translator/TranslateBatch"
+	Translator_TranslateStream_FullMethodName = "/translate.Translator/TranslateStream"
+	Translator_ListLanguages_FullMethodName   = "/translate.Translator/ListLanguages"
 )
 
 // TranslatorClient is the client API for Translator service.
@@ -30,6 +34,14 @@ const (
 type TranslatorClient interface {
 	// Translates text from the source language to the target language.
 	Translate(ctx context.Context, in *TranslationRequest, opts ...grpc.CallOption) (*TranslationResponse, error)
+	// Translates many independent items, reporting failures per item.
+	BatchTranslate(ctx context.Context, in *BatchTranslationRequest, opts ...grpc.CallOption) (*BatchTranslationResponse, error)
+	// Translates many segments of one language pair in a single call, reporting failures per segment.
+	TranslateBatch(ctx context.Context, in *TranslationBatchRequest, opts ...grpc.CallOption) (*TranslationBatchResponse, error)
+	// Translates text, streaming each sentence as soon as it is translated.
+	TranslateStream(ctx context.Context, in *TranslationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranslationSegment], error)
+	// Lists the language pairs that can be translated.
+	ListLanguages(ctx context.Context, in *ListLanguagesRequest, opts ...grpc.CallOption) (*ListLanguagesResponse, error)
 }
 
 type translatorClient struct {
@@ -50,6 +62,55 @@ func (c *translatorClient) Translate(ctx context.Context, in *TranslationRequest
 	return out, nil
 }
 
+func (c *translatorClient) BatchTranslate(ctx context.Context, in *BatchTranslationRequest, opts ...grpc.CallOption) (*BatchTranslationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchTranslationResponse)
+	err := c.cc.Invoke(ctx, Translator_BatchTranslate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translatorClient) TranslateBatch(ctx context.Context, in *TranslationBatchRequest, opts ...grpc.CallOption) (*TranslationBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranslationBatchResponse)
+	err := c.cc.Invoke(ctx, Translator_TranslateBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translatorClient) TranslateStream(ctx context.Context, in *TranslationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TranslationSegment], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Translator_ServiceDesc.Streams[0], Translator_TranslateStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TranslationRequest, TranslationSegment]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Translator_TranslateStreamClient = grpc.ServerStreamingClient[TranslationSegment]
+
+func (c *translatorClient) ListLanguages(ctx context.Context, in *ListLanguagesRequest, opts ...grpc.CallOption) (*ListLanguagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLanguagesResponse)
+	err := c.cc.Invoke(ctx, Translator_ListLanguages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TranslatorServer is the server API for Translator service.
 // All implementations must embed UnimplementedTranslatorServer
 // for forward compatibility.
@@ -58,6 +119,14 @@ func (c *translatorClient) Translate(ctx context.Context, in *TranslationRequest
 type TranslatorServer interface {
 	// Translates text from the source language to the target language.
 	Translate(context.Context, *TranslationRequest) (*TranslationResponse, error)
+	// Translates many independent items, reporting failures per item.
+	BatchTranslate(context.Context, *BatchTranslationRequest) (*BatchTranslationResponse, error)
+	// Translates many segments of one language pair in a single call, reporting failures per segment.
+	TranslateBatch(context.Context, *TranslationBatchRequest) (*TranslationBatchResponse, error)
+	// Translates text, streaming each sentence as soon as it is translated.
+	TranslateStream(*TranslationRequest, grpc.ServerStreamingServer[TranslationSegment]) error
+	// Lists the language pairs that can be translated.
+	ListLanguages(context.Context, *ListLanguagesRequest) (*ListLanguagesResponse, error)
 	mustEmbedUnimplementedTranslatorServer()
 }
 
@@ -71,6 +140,18 @@ type UnimplementedTranslatorServer struct{}
 func (UnimplementedTranslatorServer) Translate(context.Context, *TranslationRequest) (*TranslationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Translate not implemented")
 }
+func (UnimplementedTranslatorServer) BatchTranslate(context.Context, *BatchTranslationRequest) (*BatchTranslationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchTranslate not implemented")
+}
+func (UnimplementedTranslatorServer) TranslateBatch(context.Context, *TranslationBatchRequest) (*TranslationBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TranslateBatch not implemented")
+}
+func (UnimplementedTranslatorServer) TranslateStream(*TranslationRequest, grpc.ServerStreamingServer[TranslationSegment]) error {
+	return status.Errorf(codes.Unimplemented, "method TranslateStream not implemented")
+}
+func (UnimplementedTranslatorServer) ListLanguages(context.Context, *ListLanguagesRequest) (*ListLanguagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLanguages not implemented")
+}
 func (UnimplementedTranslatorServer) mustEmbedUnimplementedTranslatorServer() {}
 func (UnimplementedTranslatorServer) testEmbeddedByValue()                    {}
 
@@ -110,6 +191,71 @@ func _Translator_Translate_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Translator_BatchTranslate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchTranslationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslatorServer).BatchTranslate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Translator_BatchTranslate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslatorServer).BatchTranslate(ctx, req.(*BatchTranslationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Translator_TranslateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranslationBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslatorServer).TranslateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Translator_TranslateBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslatorServer).TranslateBatch(ctx, req.(*TranslationBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Translator_TranslateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TranslationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TranslatorServer).TranslateStream(m, &grpc.GenericServerStream[TranslationRequest, TranslationSegment]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Translator_TranslateStreamServer = grpc.ServerStreamingServer[TranslationSegment]
+
+func _Translator_ListLanguages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLanguagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranslatorServer).ListLanguages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Translator_ListLanguages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranslatorServer).ListLanguages(ctx, req.(*ListLanguagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Translator_ServiceDesc is the grpc.ServiceDesc for Translator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -121,7 +267,25 @@ var Translator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Translate",
 			Handler:    _Translator_Translate_Handler,
 		},
+		{
+			MethodName: "BatchTranslate",
+			Handler:    _Translator_BatchTranslate_Handler,
+		},
+		{
+			MethodName: "TranslateBatch",
+			Handler:    _Translator_TranslateBatch_Handler,
+		},
+		{
+			MethodName: "ListLanguages",
+			Handler:    _Translator_ListLanguages_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TranslateStream",
+			Handler:       _Translator_TranslateStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "translate.proto",
 }
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	translatepb "service/translationsapi/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// Translator is a machine translation backend; implementations must be safe
+// for concurrent use
+type Translator interface {
+	// Name identifies the backend in configuration and responses
+	Name() string
+	// Translate returns one translation per text, in order
+	Translate(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error)
+	// Languages lists the language pairs the backend can translate
+	Languages(ctx context.Context) ([]languagePair, error)
+}
+
+// translatorRouter selects the Translator for each language pair
+type translatorRouter struct {
+	fallback Translator
+	pairs    map[string]Translator // Keyed by pairKey
+	backends []Translator          // Every distinct backend, fallback first
+}
+
+// newTranslatorRouter creates the backends named by TRANSLATION_BACKEND, the
+// default for every pair, and TRANSLATION_BACKENDS, per pair overrides
+// formatted as "en:de=libretranslate,en:la=dictionary"
+func newTranslatorRouter() (*translatorRouter, error) {
+	created := make(map[string]Translator)
+	router := &translatorRouter{pairs: make(map[string]Translator)}
+	backend := func(name string) (Translator, error) {
+		if t, ok := created[name]; ok {
+			return t, nil
+		}
+		t, err := newTranslator(name)
+		if err != nil {
+			return nil, err
+		}
+		created[name] = t
+		router.backends = append(router.backends, t)
+		return t, nil
+	}
+
+	var err error
+	router.fallback, err = backend(strings.TrimSpace(getEnvWithDefault("TRANSLATION_BACKEND", "argos")))
+	if err != nil {
+		return nil, err
+	}
+
+	err = parsePairSettings("TRANSLATION_BACKENDS", func(pair, name string) error {
+		t, err := backend(name)
+		if err != nil {
+			return err
+		}
+		router.pairs[pair] = t
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return router, nil
+}
+
+// newTranslator creates a backend by name from its environment variables
+func newTranslator(name string) (Translator, error) {
+	switch name {
+	case "argos":
+		return newGrpcTranslator(name, getEnv("TRANSLATE_URL"))
+	case "libretranslate":
+		return newLibreTranslator(getEnv("LIBRETRANSLATE_URL"), getEnvWithDefault("LIBRETRANSLATE_API_KEY", "")), nil
+	case "dictionary":
+		return loadDictionaryTranslator(getEnv("DICTIONARY_FILE"))
+	case "fake":
+		return &fakeTranslator{}, nil
+	}
+	return nil, fmt.Errorf("unknown translation backend %q", name)
+}
+
+// For returns the backend translating a language pair
+func (r *translatorRouter) For(sourceLang, targetLang string) Translator {
+	if t, ok := r.pairs[pairKey(sourceLang, targetLang)]; ok {
+		return t
+	}
+	return r.fallback
+}
+
+// Languages lists the pairs each backend supports among those routed to it.
+// Backends that cannot list their pairs are skipped, so that one backend
+// being down does not hide the others' pairs; it fails only if every backend
+// does.
+func (r *translatorRouter) Languages(ctx context.Context) ([]languagePair, error) {
+	var pairs []languagePair
+	var errs []error
+	for _, t := range r.backends {
+		supported, err := t.Languages(ctx)
+		if err != nil {
+			log.Printf("Error listing languages of the %s backend: %v", t.Name(), err)
+			errs = append(errs, fmt.Errorf("%s: %w", t.Name(), err))
+			continue
+		}
+		for _, pair := range supported {
+			if r.For(pair.SourceLanguage, pair.TargetLanguage) == t {
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+	if len(errs) == len(r.backends) {
+		return nil, errors.Join(errs...)
+	}
+	return pairs, nil
+}
+
+// Close releases the backends' connections
+func (r *translatorRouter) Close() {
+	for _, t := range r.backends {
+		if closer, ok := t.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+}
+
+// grpcTranslator is a Translator backed by the translate.Translator gRPC
+// service, such as the Python Argos translation service
+type grpcTranslator struct {
+	name   string
+	conn   *grpc.ClientConn
+	client translatepb.TranslatorClient
+}
+
+// newGrpcTranslator creates a client for the translation service at url
+func newGrpcTranslator(name, url string) (*grpcTranslator, error) {
+	conn, err := grpc.NewClient(url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to translation service: %w", err)
+	}
+	return &grpcTranslator{name: name, conn: conn, client: translatepb.NewTranslatorClient(conn)}, nil
+}
+
+func (t *grpcTranslator) Name() string {
+	return t.name
+}
+
+func (t *grpcTranslator) Translate(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	res, err := t.client.TranslateBatch(ctx, &translatepb.TranslationBatchRequest{
+		Texts:          texts,
+		SourceLanguage: sourceLang,
+		TargetLanguage: targetLang,
+	})
+	if err != nil {
+		return nil, &backendError{service: "translation", err: err}
+	}
+	if len(res.Errors) > 0 {
+		// Every text is needed, so a failed segment fails the call, as
+		// retryable when the backend says retrying may succeed
+		failure := res.Errors[0]
+		code := codes.Internal
+		if failure.Retryable {
+			code = codes.Unavailable
+		}
+		return nil, &backendError{service: "translation", err: status.Errorf(code, "segment %d: %s (%s)", failure.Index, failure.Error, failure.ErrorCode)}
+	}
+	return res.Translations, nil
+}
+
+func (t *grpcTranslator) Languages(ctx context.Context) ([]languagePair, error) {
+	res, err := t.client.ListLanguages(ctx, &translatepb.ListLanguagesRequest{})
+	if err != nil {
+		return nil, &backendError{service: "translation", err: err}
+	}
+	pairs := make([]languagePair, len(res.Pairs))
+	for i, pair := range res.Pairs {
+		pairs[i] = languagePair{SourceLanguage: pair.SourceLanguage, TargetLanguage: pair.TargetLanguage}
+	}
+	return pairs, nil
+}
+
+func (t *grpcTranslator) Close() error {
+	return t.conn.Close()
+}
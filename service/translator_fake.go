@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// fakeLanguages are the languages the fakeTranslator supports by default
+var fakeLanguages = []string{"en", "es", "fr", "de", "it", "pt", "zh", "ja"}
+
+// fakeTranslator is an in-process Translator for tests and for running the
+// service without a translation backend. It prefixes each text with the
+// target language, e.g. "[es] Hello", and supports every pair of
+// fakeLanguages unless Pairs is set.
+type fakeTranslator struct {
+	Pairs []languagePair
+	Err   error // Returned by Translate when set
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (t *fakeTranslator) Name() string {
+	return "fake"
+}
+
+func (t *fakeTranslator) Translate(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	t.mu.Lock()
+	t.calls++
+	t.mu.Unlock()
+
+	if t.Err != nil {
+		return nil, t.Err
+	}
+	translations := make([]string, len(texts))
+	for i, text := range texts {
+		translations[i] = "[" + targetLang + "] " + text
+	}
+	return translations, nil
+}
+
+func (t *fakeTranslator) Languages(ctx context.Context) ([]languagePair, error) {
+	if t.Pairs != nil {
+		return t.Pairs, nil
+	}
+	var pairs []languagePair
+	for _, source := range fakeLanguages {
+		for _, target := range fakeLanguages {
+			if source != target {
+				pairs = append(pairs, languagePair{SourceLanguage: source, TargetLanguage: target})
+			}
+		}
+	}
+	return pairs, nil
+}
+
+// Calls returns the number of Translate calls made
+func (t *fakeTranslator) Calls() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.calls
+}
@@ -1,6 +1,9 @@
 import grpc
 from concurrent import futures
-from translate_pb2 import TranslationRequest, TranslationResponse
+from translate_pb2 import (
+    TranslationRequest, TranslationResponse, TranslationBatchRequest, TranslationBatchResponse, SegmentError,
+    LanguagePair, ListLanguagesResponse
+)
 from translate_pb2_grpc import TranslatorServicer, add_TranslatorServicer_to_server
 from translator import ArgosTranslator
 import logging
@@ -20,6 +23,29 @@ class TranslatorService(TranslatorServicer):
             source_language=request.source_language
         )
         return TranslationResponse(translation=text)
+
+    def TranslateBatch(self, request: TranslationBatchRequest, context):
+        translations, errors = self.translator.translate_batch(
+            texts=list(request.texts),
+            target_language=request.target_language,
+            source_language=request.source_language
+        )
+        for index, error in errors.items():
+            logging.error(f"Error translating segment {index}: {error}")
+        return TranslationBatchResponse(
+            translations=translations,
+            errors=[
+                SegmentError(index=index, error=error, error_code="backend_error")
+                for index, error in sorted(errors.items())
+            ]
+        )
+
+    def ListLanguages(self, request, context):
+        pairs = [
+            LanguagePair(source_language=source, target_language=target)
+            for source, target in self.translator.list_pairs()
+        ]
+        return ListLanguagesResponse(pairs=pairs)
     
 
 def serve():
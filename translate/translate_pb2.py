@@ -24,17 +24,45 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0ftranslate.proto\x12\ttranslate\"T\n\x12TranslationRequest\x12\x0c\n\x04text\x18\x01 \x01(\t\x12\x17\n\x0fsource_language\x18\x02 \x01(\t\x12\x17\n\x0ftarget_language\x18\x03 \x01(\t\"*\n\x13TranslationResponse\x12\x13\n\x0btranslation\x18\x01 \x01(\t2X\n\nTranslator\x12J\n\tTranslate\x12\x1d.translate.TranslationRequest\x1a\x1e.translate.TranslationResponseb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x0ftranslate.proto\x12\ttranslate\"\xae\x01\n\x12TranslationRequest\x12\x0c\n\x04text\x18\x01 \x01(\t\x12\x17\n\x0fsource_language\x18\x02 \x01(\t\x12\x17\n\x0ftarget_language\x18\x03 \x01(\t\x12\x14\n\x0cmax_distance\x18\x04 \x01(\x01\x12\x12\n\nexact_only\x18\x05 \x01(\x08\x12\r\n\x05\x64\x65\x62ug\x18\x06 \x01(\x08\x12\x0f\n\x07verbose\x18\x07 \x01(\x08\x12\x0e\n\x06tenant\x18\x08 \x01(\t\"\xbe\x01\n\x0e\x43\x61\x63heCandidate\x12\x10\n\x08sentence\x18\x01 \x01(\x05\x12\n\n\x02id\x18\x02 \x01(\x03\x12\x13\n\x0bsource_text\x18\x03 \x01(\t\x12\x13\n\x0btarget_text\x18\x04 \x01(\t\x12\x10\n\x08\x64istance\x18\x05 \x01(\x01\x12\r\n\x05score\x18\x06 \x01(\x01\x12\x0f\n\x07outcome\x18\x07 \x01(\t\x12\x10\n\x08selected\x18\x08 \x01(\x08\x12\x0e\n\x06source\x18\t \x01(\t\x12\x10\n\x08priority\x18\n \x01(\x05\"\xcd\x01\n\rSegmentDetail\x12\r\n\x05index\x18\x01 \x01(\x05\x12\x17\n\x0fsource_language\x18\x02 \x01(\t\x12\x17\n\x0ftarget_language\x18\x03 \x01(\t\x12\x0e\n\x06source\x18\x04 \x01(\t\x12\x13\n\x0btranslation\x18\x05 \x01(\t\x12\r\n\x05\x63\x61\x63he\x18\x06 \x01(\t\x12\x10\n\x08\x64istance\x18\x07 \x01(\x01\x12\x10\n\x08\x63\x61\x63he_id\x18\x08 \x01(\x03\x12\x12\n\nlatency_ms\x18\t \x01(\x03\x12\x0f\n\x07\x62\x61\x63kend\x18\n \x01(\t\"\xa0\x02\n\x13TranslationResponse\x12\x13\n\x0btranslation\x18\x01 \x01(\t\x12\x10\n\x08\x64istance\x18\x02 \x01(\x01\x12-\n\ncandidates\x18\x03 \x03(\x0b\x32\x19.translate.CacheCandidate\x12\x19\n\x11\x64\x65tected_language\x18\x04 \x01(\t\x12\x1c\n\x14\x64\x65tection_confidence\x18\x05 \x01(\x01\x12\r\n\x05route\x18\x06 \x03(\t\x12*\n\x08segments\x18\x07 \x03(\x0b\x32\x18.translate.SegmentDetail\x12\x13\n\x0bglossary_id\x18\x08 \x01(\x03\x12*\n\x07\x66lagged\x18\t \x03(\x0b\x32\x19.translate.FlaggedSegment\"D\n\x0e\x46laggedSegment\x12\r\n\x05index\x18\x01 \x01(\x05\x12\x0f\n\x07missing\x18\x02 \x03(\t\x12\x12\n\nduplicated\x18\x03 \x03(\t\"r\n\x14\x42\x61tchTranslationItem\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04text\x18\x02 \x01(\t\x12\x17\n\x0fsource_language\x18\x03 \x01(\t\x12\x17\n\x0ftarget_language\x18\x04 \x01(\t\x12\x0e\n\x06tenant\x18\x05 \x01(\t\"I\n\x17\x42\x61tchTranslationRequest\x12.\n\x05items\x18\x01 \x03(\x0b\x32\x1f.translate.BatchTranslationItem\"\xd4\x01\n\x16\x42\x61tchTranslationResult\x12\n\n\x02id\x18\x01 \x01(\t\x12\x13\n\x0btranslation\x18\x02 \x01(\t\x12\r\n\x05\x65rror\x18\x03 \x01(\t\x12\x12\n\nerror_code\x18\x04 \x01(\t\x12\x11\n\tretryable\x18\x05 \x01(\x08\x12*\n\x07\x66lagged\x18\x06 \x03(\x0b\x32\x19.translate.FlaggedSegment\x12\x19\n\x11\x64\x65tected_language\x18\x07 \x01(\t\x12\x1c\n\x14\x64\x65tection_confidence\x18\x08 \x01(\x01\"N\n\x18\x42\x61tchTranslationResponse\x12\x32\n\x07results\x18\x01 \x03(\x0b\x32!.translate.BatchTranslationResult\"Z\n\x17TranslationBatchRequest\x12\r\n\x05texts\x18\x01 \x03(\t\x12\x17\n\x0fsource_language\x18\x02 \x01(\t\x12\x17\n\x0ftarget_language\x18\x03 \x01(\t\"\x94\x01\n\x18TranslationBatchResponse\x12\x14\n\x0ctranslations\x18\x01 \x03(\t\x12\'\n\x06\x65rrors\x18\x02 \x03(\x0b\x32\x17.translate.SegmentError\x12\x1a\n\x12\x64\x65tected_languages\x18\x03 \x03(\t\x12\x1d\n\x15\x64\x65tection_confidences\x18\x04 \x03(\x01\"S\n\x0cSegmentError\x12\r\n\x05index\x18\x01 \x01(\x05\x12\r\n\x05\x65rror\x18\x02 \x01(\t\x12\x12\n\nerror_code\x18\x03 \x01(\t\x12\x11\n\tretryable\x18\x04 \x01(\x08\"\xb4\x01\n\x12TranslationSegment\x12\r\n\x05index\x18\x01 \x01(\x05\x12\x0e\n\x06source\x18\x02 \x01(\t\x12\x13\n\x0btranslation\x18\x03 \x01(\t\x12\x0f\n\x07leading\x18\x04 \x01(\t\x12\x10\n\x08trailing\x18\x05 \x01(\t\x12\x0e\n\x06\x63\x61\x63hed\x18\x06 \x01(\x08\x12\x12\n\nelapsed_ms\x18\x07 \x01(\x03\x12\x0f\n\x07missing\x18\x08 \x03(\t\x12\x12\n\nduplicated\x18\t \x03(\t\"\x16\n\x14ListLanguagesRequest\"@\n\x0cLanguagePair\x12\x17\n\x0fsource_language\x18\x01 \x01(\t\x12\x17\n\x0ftarget_language\x18\x02 \x01(\t\"?\n\x15ListLanguagesResponse\x12&\n\x05pairs\x18\x01 \x03(\x0b\x32\x17.translate.LanguagePair2\xb5\x03\n\nTranslator\x12J\n\tTranslate\x12\x1d.translate.TranslationRequest\x1a\x1e.translate.TranslationResponse\x12Y\n\x0e\x42\x61tchTranslate\x12\".translate.BatchTranslationRequest\x1a#.translate.BatchTranslationResponse\x12Y\n\x0eTranslateBatch\x12\".translate.TranslationBatchRequest\x1a#.translate.TranslationBatchResponse\x12Q\n\x0fTranslateStream\x12\x1d.translate.TranslationRequest\x1a\x1d.translate.TranslationSegment0\x01\x12R\n\rListLanguages\x12\x1f.translate.ListLanguagesRequest\x1a .translate.ListLanguagesResponseb\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
 _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'translate_pb2', _globals)
 if not _descriptor._USE_C_DESCRIPTORS:
   DESCRIPTOR._loaded_options = None
-  _globals['_TRANSLATIONREQUEST']._serialized_start=30
-  _globals['_TRANSLATIONREQUEST']._serialized_end=114
-  _globals['_TRANSLATIONRESPONSE']._serialized_start=116
-  _globals['_TRANSLATIONRESPONSE']._serialized_end=158
-  _globals['_TRANSLATOR']._serialized_start=160
-  _globals['_TRANSLATOR']._serialized_end=248
+  _globals['_TRANSLATIONREQUEST']._serialized_start=31
+  _globals['_TRANSLATIONREQUEST']._serialized_end=205
+  _globals['_CACHECANDIDATE']._serialized_start=208
+  _globals['_CACHECANDIDATE']._serialized_end=398
+  _globals['_SEGMENTDETAIL']._serialized_start=401
+  _globals['_SEGMENTDETAIL']._serialized_end=606
+  _globals['_TRANSLATIONRESPONSE']._serialized_start=609
+  _globals['_TRANSLATIONRESPONSE']._serialized_end=897
+  _globals['_FLAGGEDSEGMENT']._serialized_start=899
+  _globals['_FLAGGEDSEGMENT']._serialized_end=967
+  _globals['_BATCHTRANSLATIONITEM']._serialized_start=969
+  _globals['_BATCHTRANSLATIONITEM']._serialized_end=1083
+  _globals['_BATCHTRANSLATIONREQUEST']._serialized_start=1085
+  _globals['_BATCHTRANSLATIONREQUEST']._serialized_end=1158
+  _globals['_BATCHTRANSLATIONRESULT']._serialized_start=1161
+  _globals['_BATCHTRANSLATIONRESULT']._serialized_end=1373
+  _globals['_BATCHTRANSLATIONRESPONSE']._serialized_start=1375
+  _globals['_BATCHTRANSLATIONRESPONSE']._serialized_end=1453
+  _globals['_TRANSLATIONBATCHREQUEST']._serialized_start=1455
+  _globals['_TRANSLATIONBATCHREQUEST']._serialized_end=1545
+  _globals['_TRANSLATIONBATCHRESPONSE']._serialized_start=1548
+  _globals['_TRANSLATIONBATCHRESPONSE']._serialized_end=1696
+  _globals['_SEGMENTERROR']._serialized_start=1698
+  _globals['_SEGMENTERROR']._serialized_end=1781
+  _globals['_TRANSLATIONSEGMENT']._serialized_start=1784
+  _globals['_TRANSLATIONSEGMENT']._serialized_end=1964
+  _globals['_LISTLANGUAGESREQUEST']._serialized_start=1966
+  _globals['_LISTLANGUAGESREQUEST']._serialized_end=1988
+  _globals['_LANGUAGEPAIR']._serialized_start=1990
+  _globals['_LANGUAGEPAIR']._serialized_end=2054
+  _globals['_LISTLANGUAGESRESPONSE']._serialized_start=2056
+  _globals['_LISTLANGUAGESRESPONSE']._serialized_end=2119
+  _globals['_TRANSLATOR']._serialized_start=2122
+  _globals['_TRANSLATOR']._serialized_end=2559
 # @@protoc_insertion_point(module_scope)
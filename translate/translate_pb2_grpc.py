@@ -40,6 +40,26 @@ class TranslatorStub(object):
                 request_serializer=translate__pb2.TranslationRequest.SerializeToString,
                 response_deserializer=translate__pb2.TranslationResponse.FromString,
                 _registered_method=True)
+        self.BatchTranslate = channel.unary_unary(
+                '/translate.Translator/BatchTranslate',
+                request_serializer=translate__pb2.BatchTranslationRequest.SerializeToString,
+                response_deserializer=translate__pb2.BatchTranslationResponse.FromString,
+                _registered_method=True)
+        self.TranslateBatch = channel.unary_unary(
+                '/translate.Translator/TranslateBatch',
+                request_serializer=translate__pb2.TranslationBatchRequest.SerializeToString,
+                response_deserializer=translate__pb2.TranslationBatchResponse.FromString,
+                _registered_method=True)
+        self.TranslateStream = channel.unary_stream(
+                '/translate.Translator/TranslateStream',
+                request_serializer=translate__pb2.TranslationRequest.SerializeToString,
+                response_deserializer=translate__pb2.TranslationSegment.FromString,
+                _registered_method=True)
+        self.ListLanguages = channel.unary_unary(
+                '/translate.Translator/ListLanguages',
+                request_serializer=translate__pb2.ListLanguagesRequest.SerializeToString,
+                response_deserializer=translate__pb2.ListLanguagesResponse.FromString,
+                _registered_method=True)
 
 
 class TranslatorServicer(object):
@@ -53,6 +73,34 @@ class TranslatorServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def BatchTranslate(self, request, context):
+        """Translates many independent items, reporting failures per item.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def TranslateBatch(self, request, context):
+        """Translates many segments of one language pair in a single call, reporting failures per segment.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def TranslateStream(self, request, context):
+        """Translates text, streaming each sentence as soon as it is translated.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def ListLanguages(self, request, context):
+        """Lists the language pairs that can be translated.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_TranslatorServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -61,6 +109,26 @@ def add_TranslatorServicer_to_server(servicer, server):
                     request_deserializer=translate__pb2.TranslationRequest.FromString,
                     response_serializer=translate__pb2.TranslationResponse.SerializeToString,
             ),
+            'BatchTranslate': grpc.unary_unary_rpc_method_handler(
+                    servicer.BatchTranslate,
+                    request_deserializer=translate__pb2.BatchTranslationRequest.FromString,
+                    response_serializer=translate__pb2.BatchTranslationResponse.SerializeToString,
+            ),
+            'TranslateBatch': grpc.unary_unary_rpc_method_handler(
+                    servicer.TranslateBatch,
+                    request_deserializer=translate__pb2.TranslationBatchRequest.FromString,
+                    response_serializer=translate__pb2.TranslationBatchResponse.SerializeToString,
+            ),
+            'TranslateStream': grpc.unary_stream_rpc_method_handler(
+                    servicer.TranslateStream,
+                    request_deserializer=translate__pb2.TranslationRequest.FromString,
+                    response_serializer=translate__pb2.TranslationSegment.SerializeToString,
+            ),
+            'ListLanguages': grpc.unary_unary_rpc_method_handler(
+                    servicer.ListLanguages,
+                    request_deserializer=translate__pb2.ListLanguagesRequest.FromString,
+                    response_serializer=translate__pb2.ListLanguagesResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'translate.Translator', rpc_method_handlers)
@@ -99,3 +167,111 @@ class Translator(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def BatchTranslate(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/translate.Translator/BatchTranslate',
+            translate__pb2.BatchTranslationRequest.SerializeToString,
+            translate__pb2.BatchTranslationResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def TranslateBatch(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/translate.Translator/TranslateBatch',
+            translate__pb2.TranslationBatchRequest.SerializeToString,
+            translate__pb2.TranslationBatchResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def TranslateStream(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_stream(
+            request,
+            target,
+            '/translate.Translator/TranslateStream',
+            translate__pb2.TranslationRequest.SerializeToString,
+            translate__pb2.TranslationSegment.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def ListLanguages(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/translate.Translator/ListLanguages',
+            translate__pb2.ListLanguagesRequest.SerializeToString,
+            translate__pb2.ListLanguagesResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
@@ -1,4 +1,5 @@
-import argostranslate.package, argostranslate.translate
+import argostranslate.package, argostranslate.settings, argostranslate.translate
+import ctranslate2
 
 
 class ArgosTranslator:
@@ -9,6 +10,80 @@ class ArgosTranslator:
 
         
     def translate(self, text: str, target_language: str, source_language: str) -> str:
+        translation = self.get_translation(target_language, source_language)
+        return translation.translate(text)
+
+
+    def translate_batch(self, texts: list[str], target_language: str, source_language: str) -> tuple[list[str], dict[int, str]]:
+        """
+        Translates many texts of one language pair together, in one batch of
+        the translation model. If the batch fails, or the pair is translated
+        through another language, the texts are translated one at a time.
+        Returns the translations, empty for failed texts, and the error of
+        each failed text by index.
+        """
+        translation = self.get_translation(target_language, source_language)
+        try:
+            return self.translate_together(translation, texts), {}
+        except Exception as e:
+            print(f"Batch translation failed, translating texts one at a time: {e}")
+
+        translations, errors = [], {}
+        for index, text in enumerate(texts):
+            try:
+                translations.append(translation.translate(text))
+            except Exception as e:
+                translations.append("")
+                errors[index] = str(e)
+        return translations, errors
+
+
+    def translate_together(self, translation, texts: list[str]) -> list[str]:
+        """
+        Translates texts in a single call to the package's CTranslate2 model.
+        Each text is translated as one sentence, since the service API sends
+        sentences, so Argos' sentence boundary detection is skipped.
+        """
+        translation = getattr(translation, "underlying", translation)
+        if not isinstance(translation, argostranslate.translate.PackageTranslation):
+            raise TypeError(f"{type(translation).__name__} cannot translate in batches")
+        pkg = translation.pkg
+        if translation.translator is None:
+            translation.translator = ctranslate2.Translator(
+                str(pkg.package_path / "model"), device=argostranslate.settings.device
+            )
+
+        tokenized = [pkg.tokenizer.encode(text) for text in texts]
+        target_prefix = [[pkg.target_prefix]] * len(texts) if pkg.target_prefix else None
+        results = translation.translator.translate_batch(
+            tokenized,
+            target_prefix=target_prefix,
+            replace_unknowns=True,
+            max_batch_size=argostranslate.settings.batch_size,
+            beam_size=4,
+            length_penalty=0.2,
+        )
+
+        translations = []
+        for result in results:
+            value = pkg.tokenizer.decode(result.hypotheses[0]).lstrip(" ")
+            if pkg.target_prefix and value.startswith(pkg.target_prefix):
+                value = value[len(pkg.target_prefix):].lstrip(" ")
+            translations.append(value)
+        return translations
+
+
+    def list_pairs(self) -> list[tuple[str, str]]:
+        """
+        Lists the installed (source, target) language pairs.
+        """
+        return sorted({
+            (package.from_code, package.to_code)
+            for package in argostranslate.package.get_installed_packages()
+        })
+
+
+    def get_translation(self, target_language: str, source_language: str):
         installed_languages = argostranslate.translate.get_installed_languages()
         print(f"Installed languages: {[lang.code for lang in installed_languages]}")
 
@@ -26,8 +101,7 @@ class ArgosTranslator:
             raise ValueError("Target language not installed")
         to_lang = to_lang[0]
         
-        translation = from_lang.get_translation(to_lang)
-        return translation.translate(text)
+        return from_lang.get_translation(to_lang)
 
 
     def download_language_package(self, source: str, target: str):